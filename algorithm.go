@@ -0,0 +1,66 @@
+package persiandate
+
+import "strconv"
+
+// Algorithm names one of the built-in leap rules, for code that selects the
+// conversion algorithm by name. Every Algorithm is a LeapRule underneath,
+// see WithAlgorithm and LeapRule.
+type Algorithm int
+
+const (
+	// Borkowski is the BreakTable rule. It is the default.
+	Borkowski Algorithm = iota
+
+	// Arithmetic33 is the proleptic Cycle33 rule. Like Arithmetic2820 it
+	// accepts the years -100000000000 to 100000000000, or -1000000 to
+	// 1000000 where int has 32 bits, see YearRange.
+	Arithmetic33
+
+	// Arithmetic2820 is the proleptic Cycle2820 rule
+	Arithmetic2820
+)
+
+// CustomRule is returned by (*PersianDate).Algorithm when p uses a rule set
+// with WithLeapRule that has no Algorithm name
+const CustomRule Algorithm = -1
+
+func (a Algorithm) String() string {
+	switch a {
+	case CustomRule:
+		return "CustomRule"
+	case Borkowski:
+		return "Borkowski"
+	case Arithmetic33:
+		return "Arithmetic33"
+	case Arithmetic2820:
+		return "Arithmetic2820"
+	}
+	return "Algorithm(" + strconv.Itoa(int(a)) + ")"
+}
+
+// LeapRule returns the rule a names: BreakTable for Borkowski, Cycle33 for
+// Arithmetic33 and Cycle2820 for Arithmetic2820. Any other value gives
+// BreakTable.
+func (a Algorithm) LeapRule() LeapRule {
+	switch a {
+	case Arithmetic33:
+		return Cycle33{}
+	case Arithmetic2820:
+		return Cycle2820{}
+	}
+	return BreakTable{}
+}
+
+// Algorithm returns the name of the leap rule p uses, or CustomRule if it
+// has none
+func (p *PersianDate) Algorithm() Algorithm {
+	switch p.leapRule().(type) {
+	case BreakTable:
+		return Borkowski
+	case Cycle33:
+		return Arithmetic33
+	case Cycle2820:
+		return Arithmetic2820
+	}
+	return CustomRule
+}
@@ -0,0 +1,47 @@
+package persiandate_test
+
+import (
+	"testing"
+
+	persiandate "github.com/NothingMotion/PersianDate"
+)
+
+func TestAlgorithm(t *testing.T) {
+	tests := []struct {
+		algorithm persiandate.Algorithm
+		rule      persiandate.LeapRule
+	}{
+		{persiandate.Borkowski, persiandate.BreakTable{}},
+		{persiandate.Arithmetic33, persiandate.Cycle33{}},
+		{persiandate.Arithmetic2820, persiandate.Cycle2820{}},
+	}
+
+	for _, test := range tests {
+		if got := test.algorithm.LeapRule(); got != test.rule {
+			t.Errorf("%v.LeapRule() = %v, expected %v", test.algorithm, got, test.rule)
+		}
+		pd := persiandate.New("", persiandate.WithAlgorithm(test.algorithm))
+		if got := pd.LeapRule(); got != test.rule {
+			t.Errorf("WithAlgorithm(%v) leap rule = %v, expected %v", test.algorithm, got, test.rule)
+		}
+		if got := pd.Algorithm(); got != test.algorithm {
+			t.Errorf("WithAlgorithm(%v) Algorithm() = %v", test.algorithm, got)
+		}
+		if got := persiandate.New("", persiandate.WithLeapRule(test.rule)).Algorithm(); got != test.algorithm {
+			t.Errorf("WithLeapRule(%v) Algorithm() = %v, expected %v", test.rule, got, test.algorithm)
+		}
+	}
+
+	if got := persiandate.New("").Algorithm(); got != persiandate.Borkowski {
+		t.Errorf("default algorithm = %v, expected Borkowski", got)
+	}
+	if got := persiandate.New("", persiandate.WithLeapRule(persiandate.Astronomical{})).Algorithm(); got != persiandate.CustomRule {
+		t.Errorf("Astronomical Algorithm() = %v, expected CustomRule", got)
+	}
+
+	// The proleptic algorithm accepts years outside the break table
+	pd := persiandate.New("", persiandate.WithAlgorithm(persiandate.Arithmetic33))
+	if g, err := pd.ToGregorianE(-100, 7, 1); err != nil || g.String() != "521-09-23" {
+		t.Errorf("Arithmetic33 ToGregorianE(-100, 7, 1) = %v, %v, expected 521-09-23", g, err)
+	}
+}
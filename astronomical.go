@@ -0,0 +1,180 @@
+package persiandate
+
+import "math"
+
+// Astronomical starts every Jalali year on the day of the March equinox as
+// observed at the 52.5°E meridian (UTC+3:30), the rule of the official
+// Solar Hijri calendar: if the equinox falls before noon that day is
+// 1 Farvardin, otherwise the next day is. Leap years and the length of
+// Esfand follow from the distance between two consecutive new years.
+//
+// The equinox is computed offline with the method of chapter 27 of Jean
+// Meeus' Astronomical Algorithms, which is within about a minute of the true
+// moment, and converted to UT with the ΔT polynomials of Espenak and Meeus.
+// Meeus' method covers the Gregorian years -1000 to 3000, so the rule covers
+// the Jalali years -1621 to 2378.
+//
+// Astronomical matches BreakTable, which was fitted to the equinox, for every
+// year from 1200 to 1500; the closest call is 1309, whose equinox falls six
+// seconds before noon. Of the other years both cover, they differ only on
+// 1 Farvardin of 5, 426, 492, 525, 686, 719, 1111, 1144, 1701, 2258 and 2291.
+// In each of them the equinox is within 7 minutes of noon, which is less
+// than the uncertainty of ΔT that far from the present and of the older solar
+// theory and fixed estimate of the slowing of the Earth's rotation the break
+// table was built from. Neither rule can be trusted to the day in those years.
+type Astronomical struct{}
+
+func (Astronomical) String() string { return "Astronomical" }
+
+func (Astronomical) YearRange() (int, int) {
+	return -1621, 2378
+}
+
+// Nowruz returns the day of the March equinox in Iran, or the day after it if
+// the equinox falls at or after noon
+func (Astronomical) Nowruz(jy int) JDN {
+	// Julian Date of the equinox in UT, moved to the 52.5°E meridian. Adding
+	// 0.5 makes local midnight the start of the day.
+	local := seasonStart(jy+621, Spring) + iranOffset + 0.5
+	day := math.Floor(local)
+	if local-day >= 0.5 {
+		day++
+	}
+	return JDN(day)
+}
+
+func (a Astronomical) IsLeap(jy int) bool {
+	return a.Nowruz(jy+1)-a.Nowruz(jy) == 366
+}
+
+// iranOffset is the offset of the 52.5°E meridian from UTC, 3h30m, in days
+const iranOffset = 3.5 / 24
+
+// seasonStart returns the Julian Date (UT) of the equinox or solstice that
+// starts the season s in the Gregorian year gy
+func seasonStart(gy int, s Season) float64 {
+	var y float64
+	var c [5]float64
+	if gy < 1000 {
+		y = float64(gy) / 1000
+		c = meanSeasonsBefore1000[s-1]
+	} else {
+		y = float64(gy-2000) / 1000
+		c = meanSeasonsAfter1000[s-1]
+	}
+	jde0 := c[0] + y*(c[1]+y*(c[2]+y*(c[3]+y*c[4])))
+
+	// Correct the mean moment with the periodic terms of table 27.C
+	t := (jde0 - 2451545) / 36525
+	w := degrees(35999.373*t - 2.47)
+	dl := 1 + 0.0334*math.Cos(w) + 0.0007*math.Cos(2*w)
+	var sum float64
+	for _, term := range equinoxTerms {
+		sum += term[0] * math.Cos(degrees(term[1]+term[2]*t))
+	}
+	jde := jde0 + 0.00001*sum/dl
+
+	// Spring starts about a fifth of the way through the Gregorian year
+	return jde - deltaT(float64(gy)+0.2+0.25*float64(s-1))/86400
+}
+
+// meanSeasonsBefore1000 are the coefficients of the polynomials in
+// Y = year/1000 giving the mean March equinox, June solstice, September
+// equinox and December solstice of the years -1000 to 1000 (Meeus' table 27.A)
+var meanSeasonsBefore1000 = [4][5]float64{
+	{1721139.29189, 365242.13740, 0.06134, 0.00111, -0.00071},
+	{1721233.25401, 365241.72562, -0.05323, 0.00907, 0.00025},
+	{1721325.70455, 365242.49558, -0.11677, -0.00297, 0.00074},
+	{1721414.39987, 365242.88257, -0.00769, -0.00933, -0.00006},
+}
+
+// meanSeasonsAfter1000 are the same for the years 1000 to 3000, in
+// Y = (year-2000)/1000 (Meeus' table 27.B)
+var meanSeasonsAfter1000 = [4][5]float64{
+	{2451623.80984, 365242.37404, 0.05169, -0.00411, -0.00057},
+	{2451716.56767, 365241.62603, 0.00325, 0.00888, -0.00030},
+	{2451810.21715, 365242.01767, -0.11575, 0.00337, 0.00078},
+	{2451900.05952, 365242.74049, -0.06223, -0.00823, 0.00032},
+}
+
+// equinoxTerms are the amplitude A and the angles B and C, in degrees, of
+// the periodic terms A*cos(B+C*T) of Meeus' table 27.C
+var equinoxTerms = [...][3]float64{
+	{485, 324.96, 1934.136},
+	{203, 337.23, 32964.467},
+	{199, 342.08, 20.186},
+	{182, 27.85, 445267.112},
+	{156, 73.14, 45036.886},
+	{136, 171.52, 22518.443},
+	{77, 222.54, 65928.934},
+	{74, 296.72, 3034.906},
+	{70, 243.58, 9037.513},
+	{58, 119.81, 33718.147},
+	{52, 297.17, 150.678},
+	{50, 21.02, 2281.226},
+	{45, 247.54, 29929.562},
+	{44, 325.15, 31555.956},
+	{29, 60.93, 4443.417},
+	{18, 155.12, 67555.328},
+	{17, 288.79, 4562.452},
+	{16, 198.04, 62894.029},
+	{14, 199.76, 31436.921},
+	{12, 95.39, 14577.848},
+	{12, 287.11, 31931.756},
+	{12, 320.81, 34777.259},
+	{9, 227.73, 1222.114},
+	{8, 15.45, 16859.074},
+}
+
+func degrees(d float64) float64 {
+	return d * math.Pi / 180
+}
+
+// deltaT returns TT-UT in seconds at the decimal year y, using the
+// polynomials of Espenak and Meeus (NASA, 2006)
+func deltaT(y float64) float64 {
+	// poly evaluates c[0] + c[1]*t + c[2]*t*t + ...
+	poly := func(t float64, c ...float64) float64 {
+		var sum float64
+		for i := len(c) - 1; i >= 0; i-- {
+			sum = sum*t + c[i]
+		}
+		return sum
+	}
+	longTerm := func(y float64) float64 {
+		u := (y - 1820) / 100
+		return -20 + 32*u*u
+	}
+
+	switch {
+	case y < -500:
+		return longTerm(y)
+	case y < 500:
+		return poly(y/100, 10583.6, -1014.41, 33.78311, -5.952053, -0.1798452, 0.022174192, 0.0090316521)
+	case y < 1600:
+		return poly((y-1000)/100, 1574.2, -556.01, 71.23472, 0.319781, -0.8503463, -0.005050998, 0.0083572073)
+	case y < 1700:
+		return poly(y-1600, 120, -0.9808, -0.01532, 1.0/7129)
+	case y < 1800:
+		return poly(y-1700, 8.83, 0.1603, -0.0059285, 0.00013336, -1.0/1174000)
+	case y < 1860:
+		return poly(y-1800, 13.72, -0.332447, 0.0068612, 0.0041116, -0.00037436, 0.0000121272, -0.0000001699, 0.000000000875)
+	case y < 1900:
+		return poly(y-1860, 7.62, 0.5737, -0.251754, 0.01680668, -0.0004473624, 1.0/233174)
+	case y < 1920:
+		return poly(y-1900, -2.79, 1.494119, -0.0598939, 0.0061966, -0.000197)
+	case y < 1941:
+		return poly(y-1920, 21.20, 0.84493, -0.076100, 0.0020936)
+	case y < 1961:
+		return poly(y-1950, 29.07, 0.407, -1.0/233, 1.0/2547)
+	case y < 1986:
+		return poly(y-1975, 45.45, 1.067, -1.0/260, -1.0/718)
+	case y < 2005:
+		return poly(y-2000, 63.86, 0.3345, -0.060374, 0.0017275, 0.000651814, 0.00002373599)
+	case y < 2050:
+		return poly(y-2000, 62.92, 0.32217, 0.005589)
+	case y < 2150:
+		return longTerm(y) - 0.5628*(2150-y)
+	}
+	return longTerm(y)
+}
@@ -0,0 +1,68 @@
+package persiandate_test
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	persiandate "github.com/NothingMotion/PersianDate"
+)
+
+func TestAstronomicalMatchesBreakTable(t *testing.T) {
+	astronomical := persiandate.Astronomical{}
+	breakTable := persiandate.BreakTable{}
+
+	for year := 1200; year <= 1500; year++ {
+		if a, b := astronomical.Nowruz(year), breakTable.Nowruz(year); a != b {
+			t.Errorf("Astronomical 1 Farvardin %d = %v, BreakTable %v", year, a.ToGregorian(), b.ToGregorian())
+		}
+	}
+	if years := persiandate.CompareLeapRules(astronomical, breakTable, 1200, 1500); len(years) != 0 {
+		t.Errorf("Astronomical and BreakTable disagree on the leap years %v", years)
+	}
+
+	// The documented differences, where the equinox is within minutes of noon
+	var differ []int
+	for year := -61; year <= 2378; year++ {
+		if astronomical.Nowruz(year) != breakTable.Nowruz(year) {
+			differ = append(differ, year)
+		}
+	}
+	if expected := []int{5, 426, 492, 525, 686, 719, 1111, 1144, 1701, 2258, 2291}; !slices.Equal(differ, expected) {
+		t.Errorf("Astronomical and BreakTable differ on %v, expected %v", differ, expected)
+	}
+}
+
+func TestAstronomicalNoonRule(t *testing.T) {
+	pd := persiandate.New("", persiandate.WithLeapRule(persiandate.Astronomical{}))
+
+	tests := []struct {
+		year      int
+		gregorian string
+	}{
+		{1403, "2024-03-20"}, // equinox 2024-03-20 03:06 UTC, 06:36 in Tehran
+		{1404, "2025-03-21"}, // equinox 2025-03-20 09:01 UTC, 12:31 in Tehran
+		{1405, "2026-03-21"}, // equinox 2026-03-20 14:46 UTC
+		{1309, "1930-03-21"}, // equinox six seconds before noon
+	}
+
+	for _, test := range tests {
+		if g := pd.ToGregorian(test.year, 1, 1); g.String() != test.gregorian {
+			t.Errorf("Astronomical 1 Farvardin %d = %v, expected %s", test.year, g, test.gregorian)
+		}
+	}
+
+	// Esfand has 30 days exactly when the next equinox is 366 days away
+	for year := 1390; year <= 1420; year++ {
+		leap := pd.Difference(jalali(year, 1, 1), jalali(year+1, 1, 1)) == 366
+		_, err := pd.ToGregorianE(year, 12, 30)
+		if pd.IsLeapYearJalali(year) != leap || (pd.JalaliMonthLength(year, 12) == 30) != leap || (err == nil) != leap {
+			t.Errorf("Astronomical year %d: IsLeapYearJalali = %v, Esfand length = %d, 30 Esfand error = %v, expected leap %v",
+				year, pd.IsLeapYearJalali(year), pd.JalaliMonthLength(year, 12), err, leap)
+		}
+	}
+
+	if _, err := pd.ToGregorianE(2379, 1, 1); !errors.Is(err, persiandate.ErrYearOutOfRange) {
+		t.Errorf("Astronomical ToGregorianE(2379, 1, 1) error = %v, expected ErrYearOutOfRange", err)
+	}
+}
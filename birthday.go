@@ -0,0 +1,115 @@
+package persiandate
+
+// LeapDayBirthday decides which day is the birthday of someone born on
+// 30 Esfand in the years where Esfand only has 29 days
+type LeapDayBirthday int
+
+const (
+	// Esfand29 celebrates on 29 Esfand, the last day of the year. It is the default.
+	Esfand29 LeapDayBirthday = iota
+
+	// Farvardin1 celebrates on 1 Farvardin, the day after 29 Esfand
+	Farvardin1
+)
+
+// Age returns the number of birthdays from birth up to and including at,
+// which is the age in whole Jalali years on the date at. It panics if at is
+// before birth.
+func (p *PersianDate) Age(birth, at JalaliDate) int {
+	age, err := p.AgeE(birth, at)
+	if err != nil {
+		panic(err)
+	}
+	return age
+}
+
+// AgeE is like Age but returns an error instead of panicking
+func (p *PersianDate) AgeE(birth, at JalaliDate) (int, error) {
+	if err := p.validateJalaliDate(at); err != nil {
+		return 0, err
+	}
+	if at.Before(birth) {
+		return 0, ErrBeforeBirth
+	}
+
+	// The birthday in the year before at is never after at and the one in
+	// the year after at always is
+	age := at.Year - birth.Year
+	birthday, err := p.birthdayIn(birth, at.Year)
+	if err != nil {
+		return 0, err
+	}
+	if at.Before(birthday) {
+		age--
+	}
+	return age, nil
+}
+
+// NextBirthday returns the first birthday on or after from. Before birth it
+// returns the first birthday.
+func (p *PersianDate) NextBirthday(birth, from JalaliDate) JalaliDate {
+	next, err := p.NextBirthdayE(birth, from)
+	if err != nil {
+		panic(err)
+	}
+	return next
+}
+
+// NextBirthdayE is like NextBirthday but returns an error instead of panicking
+func (p *PersianDate) NextBirthdayE(birth, from JalaliDate) (JalaliDate, error) {
+	if err := p.validateJalaliDate(from); err != nil {
+		return JalaliDate{}, err
+	}
+	// A birthday can move to the next year, so start a year early
+	for year := max(birth.Year+1, from.Year-1); ; year++ {
+		birthday, err := p.birthdayIn(birth, year)
+		if err != nil {
+			return JalaliDate{}, err
+		}
+		if !birthday.Before(from) {
+			return birthday, nil
+		}
+	}
+}
+
+// DaysUntilBirthday returns the number of days from from to the next
+// birthday, 0 on the birthday itself
+func (p *PersianDate) DaysUntilBirthday(birth, from JalaliDate) int {
+	days, err := p.DaysUntilBirthdayE(birth, from)
+	if err != nil {
+		panic(err)
+	}
+	return days
+}
+
+// DaysUntilBirthdayE is like DaysUntilBirthday but returns an error instead of panicking
+func (p *PersianDate) DaysUntilBirthdayE(birth, from JalaliDate) (int, error) {
+	next, err := p.NextBirthdayE(birth, from)
+	if err != nil {
+		return 0, err
+	}
+	return p.DifferenceE(from, next)
+}
+
+// IsBirthday reports whether on is a birthday of someone born on birth.
+// The day of birth itself is not a birthday.
+func (p *PersianDate) IsBirthday(birth, on JalaliDate) bool {
+	next, err := p.NextBirthdayE(birth, on)
+	return err == nil && next.Equal(on)
+}
+
+// birthdayIn returns the birthday of birth in the Jalali year, moving
+// 30 Esfand according to the LeapDayBirthday of p in common years
+func (p *PersianDate) birthdayIn(birth JalaliDate, year int) (JalaliDate, error) {
+	if err := p.validateJalaliDate(birth); err != nil {
+		return JalaliDate{}, err
+	}
+	day := birth.Day
+	if birth.Month == 12 && day > p.JalaliMonthLength(year, 12) {
+		if p.leapDayBirthday == Farvardin1 {
+			return JalaliDate{Date: Date{Year: year + 1, Month: 1, Day: 1}}, nil
+		}
+		day = p.JalaliMonthLength(year, 12)
+	}
+	return JalaliDate{Date: Date{Year: year, Month: birth.Month, Day: day}}, nil
+}
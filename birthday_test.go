@@ -0,0 +1,114 @@
+package persiandate_test
+
+import (
+	"errors"
+	"testing"
+
+	persiandate "github.com/NothingMotion/PersianDate"
+)
+
+func TestAge(t *testing.T) {
+	pd := persiandate.New("")
+	birth := jalali(1385, 7, 15)
+
+	tests := []struct {
+		at       persiandate.JalaliDate
+		expected int
+	}{
+		{jalali(1385, 7, 15), 0},
+		{jalali(1403, 7, 14), 17},
+		{jalali(1403, 7, 15), 18}, // legal age on the 18th birthday
+		{jalali(1404, 1, 1), 18},
+		{jalali(1415, 7, 15), 30},
+		{jalali(1450, 7, 14), 64},
+		{jalali(1450, 7, 15), 65},
+	}
+
+	for _, test := range tests {
+		if got := pd.Age(birth, test.at); got != test.expected {
+			t.Errorf("Age(%v, %v) = %d, expected %d", birth, test.at, got, test.expected)
+		}
+	}
+
+	if _, err := pd.AgeE(birth, jalali(1385, 7, 14)); !errors.Is(err, persiandate.ErrBeforeBirth) {
+		t.Errorf("AgeE before birth error = %v, expected ErrBeforeBirth", err)
+	}
+	if _, err := pd.AgeE(jalali(1402, 12, 30), jalali(1403, 1, 1)); !errors.Is(err, persiandate.ErrInvalidDay) {
+		t.Errorf("AgeE(1402-12-30) error = %v, expected ErrInvalidDay", err)
+	}
+}
+
+func TestLeapDayBirthday(t *testing.T) {
+	birth := jalali(1399, 12, 30) // 1399 is a leap year, 1400 to 1402 are not
+
+	tests := []struct {
+		policy     persiandate.LeapDayBirthday
+		from       persiandate.JalaliDate
+		next       string
+		days       int
+		ageOnNext  int
+		ageDayPrev int
+	}{
+		{persiandate.Esfand29, jalali(1400, 6, 1), "1400-12-29", 209, 1, 0},
+		{persiandate.Esfand29, jalali(1403, 12, 1), "1403-12-30", 29, 4, 3},
+		{persiandate.Farvardin1, jalali(1400, 6, 1), "1401-01-01", 210, 1, 0},
+		{persiandate.Farvardin1, jalali(1401, 1, 1), "1401-01-01", 0, 1, 0},
+		{persiandate.Farvardin1, jalali(1403, 12, 1), "1403-12-30", 29, 4, 3},
+	}
+
+	for _, test := range tests {
+		pd := persiandate.New("", persiandate.WithLeapDayBirthday(test.policy))
+		next := pd.NextBirthday(birth, test.from)
+		if next.String() != test.next {
+			t.Errorf("policy %d: NextBirthday(%v, %v) = %v, expected %s", test.policy, birth, test.from, next, test.next)
+			continue
+		}
+		if days := pd.DaysUntilBirthday(birth, test.from); days != test.days {
+			t.Errorf("policy %d: DaysUntilBirthday(%v, %v) = %d, expected %d", test.policy, birth, test.from, days, test.days)
+		}
+		if !pd.IsBirthday(birth, next) || pd.IsBirthday(birth, pd.Sub(next, 1).Date()) {
+			t.Errorf("policy %d: IsBirthday should only be true on %v", test.policy, next)
+		}
+		if age := pd.Age(birth, next); age != test.ageOnNext {
+			t.Errorf("policy %d: Age(%v, %v) = %d, expected %d", test.policy, birth, next, age, test.ageOnNext)
+		}
+		if age := pd.Age(birth, pd.Sub(next, 1).Date()); age != test.ageDayPrev {
+			t.Errorf("policy %d: Age the day before %v = %d, expected %d", test.policy, next, age, test.ageDayPrev)
+		}
+	}
+
+	pd := persiandate.New("")
+	if pd.IsBirthday(birth, birth) {
+		t.Errorf("the day of birth should not be a birthday")
+	}
+	if next := pd.NextBirthday(birth, jalali(1390, 1, 1)); next.String() != "1400-12-29" {
+		t.Errorf("NextBirthday before birth = %v, expected the first birthday 1400-12-29", next)
+	}
+}
+
+func TestBirthdayEveryYear(t *testing.T) {
+	// 1419 is a common year, so with Farvardin1 its birthday is on 1 Farvardin 1420
+	expected := map[persiandate.LeapDayBirthday]int{persiandate.Esfand29: 44, persiandate.Farvardin1: 43}
+	for policy, expectedBirthdays := range expected {
+		pd := persiandate.New("", persiandate.WithLeapDayBirthday(policy))
+		birth := jalali(1375, 12, 30)
+
+		// Exactly one birthday a year, and the age goes up by one on it
+		day, _ := persiandate.JDNFromJalali(1376, 1, 1)
+		end, _ := persiandate.JDNFromJalali(1420, 1, 1)
+		age, birthdays := 0, 0
+		for ; day < end; day++ {
+			on, _ := day.ToJalali()
+			if pd.IsBirthday(birth, on) {
+				age++
+				birthdays++
+			}
+			if got := pd.Age(birth, on); got != age {
+				t.Fatalf("policy %d: Age(%v, %v) = %d, expected %d", policy, birth, on, got, age)
+			}
+		}
+		if birthdays != expectedBirthdays {
+			t.Errorf("policy %d: %d birthdays from 1376 to 1419, expected %d", policy, birthdays, expectedBirthdays)
+		}
+	}
+}
@@ -0,0 +1,195 @@
+package persiandate
+
+import (
+	"errors"
+	"strconv"
+	"time"
+)
+
+// Unit is a span of the Jalali calendar. Weeks start on Saturday and seasons
+// are the three month quarters starting in Farvardin, Tir, Mehr and Dey.
+type Unit int
+
+const (
+	UnitDay Unit = iota
+	UnitWeek
+	UnitMonth
+	UnitSeason
+	UnitYear
+)
+
+func (u Unit) String() string {
+	switch u {
+	case UnitDay:
+		return "day"
+	case UnitWeek:
+		return "week"
+	case UnitMonth:
+		return "month"
+	case UnitSeason:
+		return "season"
+	case UnitYear:
+		return "year"
+	}
+	return "Unit(" + strconv.Itoa(int(u)) + ")"
+}
+
+// StartOf returns the first day of the unit containing d, with a zero clock
+func (p *PersianDate) StartOf(d JalaliDate, unit Unit) JalaliDate {
+	start, err := p.StartOfE(d, unit)
+	if err != nil {
+		panic(err)
+	}
+	return start
+}
+
+// StartOfE is like StartOf but returns an error instead of panicking
+func (p *PersianDate) StartOfE(d JalaliDate, unit Unit) (JalaliDate, error) {
+	start, _, err := p.boundsOf(d, unit)
+	return start, err
+}
+
+// EndOf returns the last day of the unit containing d, with a zero clock
+func (p *PersianDate) EndOf(d JalaliDate, unit Unit) JalaliDate {
+	end, err := p.EndOfE(d, unit)
+	if err != nil {
+		panic(err)
+	}
+	return end
+}
+
+// EndOfE is like EndOf but returns an error instead of panicking
+func (p *PersianDate) EndOfE(d JalaliDate, unit Unit) (JalaliDate, error) {
+	_, end, err := p.boundsOf(d, unit)
+	return end, err
+}
+
+// StartOfWeek returns the Saturday of the week of d
+func (p *PersianDate) StartOfWeek(d JalaliDate) JalaliDate { return p.StartOf(d, UnitWeek) }
+
+// EndOfWeek returns the Friday of the week of d
+func (p *PersianDate) EndOfWeek(d JalaliDate) JalaliDate { return p.EndOf(d, UnitWeek) }
+
+// StartOfMonth returns the first day of the month of d
+func (p *PersianDate) StartOfMonth(d JalaliDate) JalaliDate { return p.StartOf(d, UnitMonth) }
+
+// EndOfMonth returns the last day of the month of d
+func (p *PersianDate) EndOfMonth(d JalaliDate) JalaliDate { return p.EndOf(d, UnitMonth) }
+
+// StartOfSeason returns 1 Farvardin, 1 Tir, 1 Mehr or 1 Dey
+func (p *PersianDate) StartOfSeason(d JalaliDate) JalaliDate { return p.StartOf(d, UnitSeason) }
+
+// EndOfSeason returns the last day of Khordad, Shahrivar, Azar or Esfand
+func (p *PersianDate) EndOfSeason(d JalaliDate) JalaliDate { return p.EndOf(d, UnitSeason) }
+
+// StartOfYear returns 1 Farvardin of the year of d
+func (p *PersianDate) StartOfYear(d JalaliDate) JalaliDate { return p.StartOf(d, UnitYear) }
+
+// EndOfYear returns the last day of Esfand of the year of d
+func (p *PersianDate) EndOfYear(d JalaliDate) JalaliDate { return p.EndOf(d, UnitYear) }
+
+// IsSameWeek reports whether a and b are in the same Saturday to Friday week
+func (p *PersianDate) IsSameWeek(a, b JalaliDate) bool {
+	aStart, errA := p.StartOfE(a, UnitWeek)
+	bStart, errB := p.StartOfE(b, UnitWeek)
+	return errA == nil && errB == nil && aStart == bStart
+}
+
+// IsSameMonth reports whether a and b are in the same month of the same year
+func (p *PersianDate) IsSameMonth(a, b JalaliDate) bool {
+	return a.Year == b.Year && a.Month == b.Month
+}
+
+// IsToday reports whether d is today in the location and clock of p
+func (p *PersianDate) IsToday(d JalaliDate) bool {
+	now, err := p.NowE()
+	if err != nil && !errors.Is(err, ErrBuiltinLocation) {
+		return false
+	}
+	return now.Date().Equal(d)
+}
+
+// IsWeekend reports whether d is a Friday, the Iranian weekend
+func (p *PersianDate) IsWeekend(d JalaliDate) bool {
+	weekDay, err := p.weekDay(d)
+	return err == nil && weekDay == 6
+}
+
+// boundsOf returns the first and last day of the unit containing d
+func (p *PersianDate) boundsOf(d JalaliDate, unit Unit) (JalaliDate, JalaliDate, error) {
+	if err := p.validateJalaliDate(d); err != nil {
+		return JalaliDate{}, JalaliDate{}, err
+	}
+	date := func(year, month, day int) JalaliDate {
+		return JalaliDate{Date: Date{Year: year, Month: month, Day: day}}
+	}
+
+	switch unit {
+	case UnitDay:
+		return date(d.Year, d.Month, d.Day), date(d.Year, d.Month, d.Day), nil
+	case UnitWeek:
+		jdn, err := p.jalaliToJulianDay(d.Year, d.Month, d.Day)
+		if err != nil {
+			return JalaliDate{}, JalaliDate{}, err
+		}
+		saturday := jdn - JDN(jdn).Weekday()
+		start, err := p.julianDayToJalali(saturday)
+		if err != nil {
+			return JalaliDate{}, JalaliDate{}, err
+		}
+		end, err := p.julianDayToJalali(saturday + 6)
+		return start, end, err
+	case UnitMonth:
+		return date(d.Year, d.Month, 1), date(d.Year, d.Month, p.JalaliMonthLength(d.Year, d.Month)), nil
+	case UnitSeason:
+		first := (d.Month-1)/3*3 + 1
+		return date(d.Year, first, 1), date(d.Year, first+2, p.JalaliMonthLength(d.Year, first+2)), nil
+	case UnitYear:
+		return date(d.Year, 1, 1), date(d.Year, 12, p.JalaliMonthLength(d.Year, 12)), nil
+	}
+	return JalaliDate{}, JalaliDate{}, invalidUnit(int(unit))
+}
+
+// StartOf returns midnight at the start of the unit containing t, in the
+// location of t. If midnight does not exist because of a daylight saving
+// change, it is the first instant of that day.
+func (t Time) StartOf(unit Unit) Time {
+	start, _, err := timeCalendar.boundsOf(t.Date(), unit)
+	if err != nil {
+		panic(err)
+	}
+	jdn, err := timeCalendar.jalaliToJulianDay(start.Year, start.Month, start.Day)
+	if err != nil {
+		panic(err)
+	}
+	return Time{t: dayStart(JDN(jdn), t.t.Location())}
+}
+
+// EndOf returns the last nanosecond of the unit containing t, in the
+// location of t
+func (t Time) EndOf(unit Unit) Time {
+	_, end, err := timeCalendar.boundsOf(t.Date(), unit)
+	if err != nil {
+		panic(err)
+	}
+	jdn, err := timeCalendar.jalaliToJulianDay(end.Year, end.Month, end.Day)
+	if err != nil {
+		panic(err)
+	}
+	return Time{t: dayStart(JDN(jdn+1), t.t.Location()).Add(-time.Nanosecond)}
+}
+
+// dayStart returns the first instant of the day j in loc: midnight, or the
+// end of a daylight saving gap that skips midnight. For such a gap time.Date
+// goes back to the previous day in zones west of UTC, such as America/Havana,
+// so the end of the zone period it lands in is taken instead.
+func dayStart(j JDN, loc *time.Location) time.Time {
+	g := j.ToGregorian()
+	t := time.Date(g.Year, time.Month(g.Month), g.Day, 0, 0, 0, 0, loc)
+	if y, m, d := t.Date(); y != g.Year || int(m) != g.Month || d != g.Day {
+		if _, end := t.ZoneBounds(); !end.IsZero() {
+			return end
+		}
+	}
+	return t
+}
@@ -0,0 +1,142 @@
+package persiandate_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	persiandate "github.com/NothingMotion/PersianDate"
+)
+
+func TestStartAndEndOf(t *testing.T) {
+	pd := persiandate.New("")
+
+	tests := []struct {
+		date       persiandate.JalaliDate
+		unit       persiandate.Unit
+		start, end string
+	}{
+		{jalali(1404, 1, 12), persiandate.UnitWeek, "1404-01-09", "1404-01-15"},
+		{jalali(1404, 1, 1), persiandate.UnitWeek, "1403-12-25", "1404-01-01"},
+		{jalali(1404, 1, 9), persiandate.UnitWeek, "1404-01-09", "1404-01-15"},
+		{jalali(1403, 12, 10), persiandate.UnitMonth, "1403-12-01", "1403-12-30"},
+		{jalali(1402, 12, 10), persiandate.UnitMonth, "1402-12-01", "1402-12-29"},
+		{jalali(1403, 6, 31), persiandate.UnitMonth, "1403-06-01", "1403-06-31"},
+		{jalali(1403, 8, 20), persiandate.UnitSeason, "1403-07-01", "1403-09-30"},
+		{jalali(1403, 11, 5), persiandate.UnitSeason, "1403-10-01", "1403-12-30"},
+		{jalali(1403, 3, 31), persiandate.UnitSeason, "1403-01-01", "1403-03-31"},
+		{jalali(1402, 5, 5), persiandate.UnitYear, "1402-01-01", "1402-12-29"},
+		{jalali(1402, 5, 5), persiandate.UnitDay, "1402-05-05", "1402-05-05"},
+	}
+
+	for _, test := range tests {
+		start, end := pd.StartOf(test.date, test.unit), pd.EndOf(test.date, test.unit)
+		if start.String() != test.start || end.String() != test.end {
+			t.Errorf("%v of %v = %v to %v, expected %s to %s", test.unit, test.date, start, end, test.start, test.end)
+		}
+	}
+
+	date := jalali(1403, 11, 5)
+	date.Hour = 10
+	named := []persiandate.JalaliDate{
+		pd.StartOfWeek(date), pd.EndOfWeek(date), pd.StartOfMonth(date), pd.EndOfMonth(date),
+		pd.StartOfSeason(date), pd.EndOfSeason(date), pd.StartOfYear(date), pd.EndOfYear(date),
+	}
+	expected := []string{"1403-10-29", "1403-11-05", "1403-11-01", "1403-11-30", "1403-10-01", "1403-12-30", "1403-01-01", "1403-12-30"}
+	for i, d := range named {
+		if d.String() != expected[i] || d.Hour != 0 {
+			t.Errorf("boundary %d of 1403-11-05 10:00 = %v %02d:00, expected %s 00:00", i, d, d.Hour, expected[i])
+		}
+	}
+
+	if _, err := pd.StartOfE(date, persiandate.Unit(9)); !errors.Is(err, persiandate.ErrInvalidUnit) {
+		t.Errorf("StartOfE with Unit(9) error = %v, expected ErrInvalidUnit", err)
+	}
+	if _, err := pd.EndOfE(jalali(1402, 12, 30), persiandate.UnitMonth); !errors.Is(err, persiandate.ErrInvalidDay) {
+		t.Errorf("EndOfE(1402-12-30) error = %v, expected ErrInvalidDay", err)
+	}
+}
+
+func TestWeekYearEveryWeekday(t *testing.T) {
+	pd := persiandate.New("")
+	// 1404-01-09 is a Saturday; every day of its week must map to it
+	for day := 9; day <= 15; day++ {
+		week := pd.WeekYear(1404, 1, day)
+		if week["saturday"].String() != "1404-01-09" || week["friday"].String() != "1404-01-15" {
+			t.Errorf("WeekYear(1404, 1, %d) = %v, expected 1404-01-09 to 1404-01-15", day, week)
+		}
+	}
+}
+
+func TestDatePredicates(t *testing.T) {
+	clock := persiandate.FixedClock(time.Date(2025, 3, 30, 12, 0, 0, 0, time.UTC)) // 1404-01-10 in Tehran
+	pd := persiandate.New("", persiandate.WithClock(clock), persiandate.WithLocation(persiandate.BuiltinLocation("Asia/Tehran")))
+
+	if !pd.IsSameWeek(jalali(1404, 1, 1), jalali(1403, 12, 25)) || pd.IsSameWeek(jalali(1404, 1, 1), jalali(1404, 1, 2)) {
+		t.Errorf("IsSameWeek should follow Saturday to Friday weeks across the new year")
+	}
+	if !pd.IsSameMonth(jalali(1404, 1, 1), jalali(1404, 1, 31)) || pd.IsSameMonth(jalali(1404, 1, 1), jalali(1403, 1, 1)) {
+		t.Errorf("IsSameMonth should compare the year and month")
+	}
+	if !pd.IsWeekend(jalali(1404, 1, 1)) || pd.IsWeekend(jalali(1404, 1, 2)) {
+		t.Errorf("IsWeekend should be true on Fridays only")
+	}
+	if !pd.IsToday(jalali(1404, 1, 10)) || pd.IsToday(jalali(1404, 1, 9)) {
+		t.Errorf("IsToday should compare with 1404-01-10 from the clock")
+	}
+}
+
+func TestTimeStartAndEndOf(t *testing.T) {
+	tehran := persiandate.BuiltinLocation("Asia/Tehran")
+	at := persiandate.NewTime(1403, 8, 20, 15, 30, 0, 0, tehran)
+
+	if start := at.StartOf(persiandate.UnitSeason).Std(); !start.Equal(time.Date(2024, 9, 22, 0, 0, 0, 0, tehran)) {
+		t.Errorf("StartOf(UnitSeason) = %v, expected 1403-07-01 00:00", start)
+	}
+	if end := at.EndOf(persiandate.UnitYear).Std(); !end.Equal(time.Date(2025, 3, 20, 23, 59, 59, 999999999, tehran)) {
+		t.Errorf("EndOf(UnitYear) = %v, expected 1403-12-30 23:59:59.999999999", end)
+	}
+	if kabul := at.In(persiandate.BuiltinLocation("Asia/Kabul")).StartOf(persiandate.UnitDay); kabul.Std().Format("15:04 -0700") != "00:00 +0430" {
+		t.Errorf("StartOf(UnitDay) in Kabul = %v", kabul)
+	}
+
+	// Clocks moved from 00:00 to 01:00 on 2 Farvardin 1400, so the day starts at 01:00
+	dst := persiandate.NewTime(1400, 1, 2, 12, 0, 0, 0, tehran)
+	if start := dst.StartOf(persiandate.UnitDay).Std(); start.Format("15:04 -0700") != "01:00 +0430" {
+		t.Errorf("StartOf(UnitDay) on 1400-01-02 = %v, expected 01:00 +0430", start)
+	}
+	if end := persiandate.NewTime(1400, 1, 1, 12, 0, 0, 0, tehran).EndOf(persiandate.UnitDay); !end.Add(time.Nanosecond).Equal(dst.StartOf(persiandate.UnitDay)) {
+		t.Errorf("EndOf(UnitDay) on 1400-01-01 = %v, expected just before the next day", end)
+	}
+}
+
+// TestTimeStartOfWestOfUTC checks days whose midnight is skipped in zones
+// where time.Date resolves the gap to the evening before
+func TestTimeStartOfWestOfUTC(t *testing.T) {
+	tests := []struct {
+		zone       string
+		date       persiandate.JalaliDate
+		start, end string
+	}{
+		// Clocks moved from 00:00 to 01:00 on 12 March 2023 (1401-12-21)
+		{"America/Havana", jalali(1401, 12, 21), "2023-03-12 01:00:00 -0400", "2023-03-11 23:59:59.999999999 -0500"},
+		// and on 3 September 2023 (1402-06-12)
+		{"America/Santiago", jalali(1402, 6, 12), "2023-09-03 01:00:00 -0300", "2023-09-02 23:59:59.999999999 -0400"},
+	}
+
+	for _, test := range tests {
+		loc, err := time.LoadLocation(test.zone)
+		if err != nil {
+			t.Skipf("system zoneinfo for %s unavailable: %v", test.zone, err)
+		}
+		d := test.date
+		at := persiandate.NewTime(d.Year, d.Month, d.Day, 12, 0, 0, 0, loc)
+		if start := at.StartOf(persiandate.UnitDay); start.Std().Format("2006-01-02 15:04:05 -0700") != test.start || start.Day() != d.Day {
+			t.Errorf("StartOf(UnitDay) on %v in %s = %v, expected %s", d, test.zone, start.Std(), test.start)
+		}
+		before := at.AddDate(0, 0, -1)
+		if end := before.EndOf(persiandate.UnitDay); end.Std().Format("2006-01-02 15:04:05.999999999 -0700") != test.end {
+			t.Errorf("EndOf(UnitDay) on the day before %v in %s = %v, expected %s", d, test.zone, end.Std(), test.end)
+		}
+	}
+}
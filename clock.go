@@ -0,0 +1,145 @@
+package persiandate
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock supplies the current time to Now, NowFull, Until and Since
+type Clock interface {
+	Now() time.Time
+}
+
+// systemClock reads the system time
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+// waitClock is a Clock that can also wait, which a Scheduler uses to sleep
+// until its next run. Other clocks are waited on with a time.Timer.
+type waitClock interface {
+	Clock
+	After(d time.Duration) <-chan time.Time
+}
+
+// fixedClock always reports the same time
+type fixedClock struct {
+	t time.Time
+}
+
+func (c fixedClock) Now() time.Time {
+	return c.t
+}
+
+// FixedClock returns a Clock that always reports t
+func FixedClock(t time.Time) Clock {
+	return fixedClock{t: t}
+}
+
+// FakeClock is a Clock that only moves when it is set or advanced.
+// It is safe for concurrent use.
+type FakeClock struct {
+	mu      sync.Mutex
+	now     time.Time
+	waiters []fakeWaiter
+}
+
+// fakeWaiter is a channel returned by After and how much the clock must
+// still be advanced for it to fire
+type fakeWaiter struct {
+	left time.Duration
+	c    chan time.Time
+}
+
+// NewFakeClock returns a FakeClock reporting t
+func NewFakeClock(t time.Time) *FakeClock {
+	return &FakeClock{now: t}
+}
+
+// Now returns the time the clock was last set or advanced to
+func (c *FakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// Set moves the clock to t, which may be in the past
+func (c *FakeClock) Set(t time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = t
+}
+
+// Advance moves the clock forward by d
+func (c *FakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+	c.fire(d)
+}
+
+// After returns a channel that receives the time of the clock once it has
+// been advanced by d in total. Like time.After it measures elapsed time, so
+// Set, which changes the time like a change of the system clock, does not
+// make it fire. Use NewTimer to be able to stop waiting.
+func (c *FakeClock) After(d time.Duration) <-chan time.Time {
+	return c.NewTimer(d).C
+}
+
+// FakeTimer is a timer of a FakeClock, like time.Timer
+type FakeTimer struct {
+	C     <-chan time.Time // receives the time when the timer fires
+	clock *FakeClock
+	c     chan time.Time
+}
+
+// NewTimer returns a timer that sends the time of the clock on its channel
+// once the clock has been advanced by d in total, like After
+func (c *FakeClock) NewTimer(d time.Duration) *FakeTimer {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	ch := make(chan time.Time, 1)
+	c.waiters = append(c.waiters, fakeWaiter{left: d, c: ch})
+	c.fire(0)
+	return &FakeTimer{C: ch, clock: c, c: ch}
+}
+
+// Stop prevents the timer from firing. It returns false if the timer has
+// already fired or been stopped.
+func (t *FakeTimer) Stop() bool {
+	c := t.clock
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for i, w := range c.waiters {
+		if w.c == t.c {
+			c.waiters = append(c.waiters[:i], c.waiters[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// Waiters returns how many channels returned by After and NewTimer have not
+// fired or been stopped yet. Tests use it to wait until a Scheduler is
+// sleeping before moving the clock.
+func (c *FakeClock) Waiters() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.waiters)
+}
+
+// fire counts d against the waiters and sends the time to those that are
+// due; c.mu must be held
+func (c *FakeClock) fire(d time.Duration) {
+	pending := c.waiters[:0]
+	for _, w := range c.waiters {
+		if w.left -= d; w.left > 0 {
+			pending = append(pending, w)
+		} else {
+			w.c <- c.now
+		}
+	}
+	c.waiters = pending
+}
@@ -0,0 +1,54 @@
+package persiandate_test
+
+import (
+	"testing"
+	"time"
+
+	persiandate "github.com/NothingMotion/PersianDate"
+)
+
+func TestFixedClock(t *testing.T) {
+	tehran := time.FixedZone("+0330", 3*3600+1800)
+	// 30 Esfand 1403, the last day of a leap year
+	clock := persiandate.FixedClock(time.Date(2025, 3, 20, 10, 0, 0, 0, tehran))
+	pd := persiandate.New("", persiandate.WithClock(clock), persiandate.WithLocation(tehran))
+
+	today := pd.Now().Date()
+	if today.Year != 1403 || today.Month != 12 || today.Day != 30 {
+		t.Errorf("Now() = %v, expected 1403-12-30", today)
+	}
+
+	full := pd.NowFull()
+	if full.Year != 1403 || full.Month != 12 || full.Day != 30 || full.Hour != 10 {
+		t.Errorf("NowFull() = %v, expected 1403-12-30 10:00:00", full)
+	}
+
+	nowruz := persiandate.JalaliDate{Date: persiandate.Date{Year: 1404, Month: 1, Day: 1}}
+	if days := pd.Until(nowruz); days != 1 {
+		t.Errorf("Until(1404-01-01) = %d, expected 1", days)
+	}
+	if days := pd.Since(persiandate.JalaliDate{Date: persiandate.Date{Year: 1403, Month: 12, Day: 1}}); days != 29 {
+		t.Errorf("Since(1403-12-01) = %d, expected 29", days)
+	}
+}
+
+func TestFakeClock(t *testing.T) {
+	tehran := time.FixedZone("+0330", 3*3600+1800)
+	// One second before the 1404 vernal equinox (2025-03-20 09:01:25 UTC)
+	clock := persiandate.NewFakeClock(time.Date(2025, 3, 20, 12, 31, 24, 0, tehran))
+	pd := persiandate.New("", persiandate.WithClock(clock), persiandate.WithLocation(tehran))
+
+	if today := pd.Now().Date(); today.Year != 1403 || today.Month != 12 || today.Day != 30 {
+		t.Errorf("Now() = %v, expected 1403-12-30", today)
+	}
+
+	clock.Advance(12 * time.Hour)
+	if today := pd.Now().Date(); today.Year != 1404 || today.Month != 1 || today.Day != 1 {
+		t.Errorf("Now() after Advance(12h) = %v, expected 1404-01-01", today)
+	}
+
+	clock.Set(time.Date(2024, 3, 19, 23, 0, 0, 0, tehran))
+	if today := pd.Now().Date(); today.Year != 1402 || today.Month != 12 || today.Day != 29 {
+		t.Errorf("Now() after Set = %v, expected 1402-12-29", today)
+	}
+}
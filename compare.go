@@ -0,0 +1,78 @@
+package persiandate
+
+import "cmp"
+
+// Compare compares the calendar days of j and u, ignoring the clock fields.
+// It returns -1 if j is before u, +1 if j is after u and 0 if they are the same
+// day, so it can be used with slices.SortFunc and slices.BinarySearchFunc.
+// The comparison works on the fields alone and never fails, even for dates
+// that cannot be converted.
+func (j JalaliDate) Compare(u JalaliDate) int {
+	if c := cmp.Compare(j.Year, u.Year); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(j.Month, u.Month); c != 0 {
+		return c
+	}
+	return cmp.Compare(j.Day, u.Day)
+}
+
+// Before reports whether j is an earlier day than u
+func (j JalaliDate) Before(u JalaliDate) bool {
+	return j.Compare(u) < 0
+}
+
+// After reports whether j is a later day than u
+func (j JalaliDate) After(u JalaliDate) bool {
+	return j.Compare(u) > 0
+}
+
+// Equal reports whether j and u are the same day
+func (j JalaliDate) Equal(u JalaliDate) bool {
+	return j.Compare(u) == 0
+}
+
+// IsZero reports whether every field of j is zero
+func (j JalaliDate) IsZero() bool {
+	return j.Date == Date{}
+}
+
+// Compare compares j and u including the clock and nanoseconds.
+// It follows the cmp.Compare convention like JalaliDate.Compare. The fields
+// are compared as wall clock values and the locations are ignored, so convert
+// both values to the same location (or use Time) to compare instants.
+func (j JalaliDateTime) Compare(u JalaliDateTime) int {
+	if c := j.JalaliDate.Compare(u.JalaliDate); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(j.Hour, u.Hour); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(j.Minute, u.Minute); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(j.Second, u.Second); c != 0 {
+		return c
+	}
+	return cmp.Compare(j.Nanosecond, u.Nanosecond)
+}
+
+// Before reports whether j is earlier than u
+func (j JalaliDateTime) Before(u JalaliDateTime) bool {
+	return j.Compare(u) < 0
+}
+
+// After reports whether j is later than u
+func (j JalaliDateTime) After(u JalaliDateTime) bool {
+	return j.Compare(u) > 0
+}
+
+// Equal reports whether j and u have the same date and clock
+func (j JalaliDateTime) Equal(u JalaliDateTime) bool {
+	return j.Compare(u) == 0
+}
+
+// IsZero reports whether every field of j is zero
+func (j JalaliDateTime) IsZero() bool {
+	return j.JalaliDate.IsZero() && j.Nanosecond == 0 && j.Location == nil
+}
@@ -0,0 +1,101 @@
+package persiandate_test
+
+import (
+	"slices"
+	"testing"
+
+	persiandate "github.com/NothingMotion/PersianDate"
+)
+
+func jalali(year, month, day int) persiandate.JalaliDate {
+	return persiandate.JalaliDate{Date: persiandate.Date{Year: year, Month: month, Day: day}}
+}
+
+func TestJalaliDateCompare(t *testing.T) {
+	tests := []struct {
+		a, b     persiandate.JalaliDate
+		expected int
+	}{
+		{jalali(1402, 1, 1), jalali(1402, 1, 1), 0},
+		{jalali(1402, 1, 1), jalali(1402, 1, 2), -1},
+		{jalali(1402, 2, 1), jalali(1402, 1, 31), 1},
+		{jalali(1401, 12, 29), jalali(1402, 1, 1), -1},
+		{jalali(1402, 12, 30), jalali(1403, 1, 1), -1}, // invalid dates still compare
+	}
+
+	for _, test := range tests {
+		if got := test.a.Compare(test.b); got != test.expected {
+			t.Errorf("%v.Compare(%v) = %d, expected %d", test.a, test.b, got, test.expected)
+		}
+		if got := test.a.Before(test.b); got != (test.expected < 0) {
+			t.Errorf("%v.Before(%v) = %v", test.a, test.b, got)
+		}
+		if got := test.a.After(test.b); got != (test.expected > 0) {
+			t.Errorf("%v.After(%v) = %v", test.a, test.b, got)
+		}
+		if got := test.a.Equal(test.b); got != (test.expected == 0) {
+			t.Errorf("%v.Equal(%v) = %v", test.a, test.b, got)
+		}
+	}
+
+	withClock := jalali(1402, 1, 1)
+	withClock.Hour = 23
+	if withClock.Compare(jalali(1402, 1, 1)) != 0 {
+		t.Errorf("JalaliDate.Compare should ignore the clock")
+	}
+
+	if !(persiandate.JalaliDate{}).IsZero() || jalali(1402, 1, 1).IsZero() {
+		t.Errorf("IsZero() returned the wrong result")
+	}
+}
+
+func TestJalaliDateSlices(t *testing.T) {
+	dates := []persiandate.JalaliDate{jalali(1403, 1, 1), jalali(1401, 7, 20), jalali(1402, 12, 1), jalali(1402, 1, 5)}
+	slices.SortFunc(dates, persiandate.JalaliDate.Compare)
+
+	expected := []persiandate.JalaliDate{jalali(1401, 7, 20), jalali(1402, 1, 5), jalali(1402, 12, 1), jalali(1403, 1, 1)}
+	if !slices.EqualFunc(dates, expected, persiandate.JalaliDate.Equal) {
+		t.Errorf("SortFunc = %v, expected %v", dates, expected)
+	}
+
+	i, found := slices.BinarySearchFunc(dates, jalali(1402, 12, 1), persiandate.JalaliDate.Compare)
+	if !found || i != 2 {
+		t.Errorf("BinarySearchFunc(1402-12-01) = %d, %v, expected 2, true", i, found)
+	}
+	i, found = slices.BinarySearchFunc(dates, jalali(1402, 6, 1), persiandate.JalaliDate.Compare)
+	if found || i != 2 {
+		t.Errorf("BinarySearchFunc(1402-06-01) = %d, %v, expected 2, false", i, found)
+	}
+}
+
+func TestJalaliDateTimeCompare(t *testing.T) {
+	at := func(hour, minute, second, nanosecond int) persiandate.JalaliDateTime {
+		d := jalali(1402, 6, 15)
+		d.Hour, d.Minute, d.Second = hour, minute, second
+		return persiandate.JalaliDateTime{JalaliDate: d, Nanosecond: nanosecond}
+	}
+
+	tests := []struct {
+		a, b     persiandate.JalaliDateTime
+		expected int
+	}{
+		{at(10, 0, 0, 0), at(10, 0, 0, 0), 0},
+		{at(9, 59, 59, 999), at(10, 0, 0, 0), -1},
+		{at(10, 0, 0, 1), at(10, 0, 0, 0), 1},
+		{at(10, 1, 0, 0), at(10, 0, 59, 0), 1},
+		{persiandate.JalaliDateTime{JalaliDate: jalali(1402, 6, 14)}, at(0, 0, 0, 0), -1},
+	}
+
+	for _, test := range tests {
+		if got := test.a.Compare(test.b); got != test.expected {
+			t.Errorf("%v.Compare(%v) = %d, expected %d", test.a, test.b, got, test.expected)
+		}
+		if test.a.Before(test.b) != (test.expected < 0) || test.a.After(test.b) != (test.expected > 0) || test.a.Equal(test.b) != (test.expected == 0) {
+			t.Errorf("Before/After/Equal disagree with Compare for %v and %v", test.a, test.b)
+		}
+	}
+
+	if !(persiandate.JalaliDateTime{}).IsZero() || at(0, 0, 0, 1).IsZero() {
+		t.Errorf("IsZero() returned the wrong result")
+	}
+}
@@ -0,0 +1,283 @@
+package persiandate
+
+import (
+	"errors"
+	"math/bits"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Cron is a cron expression whose day of month, month and day of week fields
+// refer to the Jalali calendar. Create it with ParseCron.
+type Cron struct {
+	p   *PersianDate
+	loc *time.Location
+
+	second, minute, hour uint64 // bit n is set when n matches
+	day, month, weekday  uint64
+	lastDay              bool // L, the last day of the month
+	anyDay, anyWeekday   bool // * or ? in the day of month or day of week field
+}
+
+// cronHorizon is how many days Next and Prev search, long enough for a
+// 30 Esfand to come around
+const cronHorizon = 12 * 366
+
+// cronField describes one field of a cron expression
+type cronField struct {
+	name     string
+	min, max int
+	names    map[string]int
+}
+
+var (
+	cronSecond = cronField{name: "second", max: 59}
+	cronMinute = cronField{name: "minute", max: 59}
+	cronHour   = cronField{name: "hour", max: 23}
+	cronDay    = cronField{name: "day of month", min: 1, max: 31}
+	cronMonth  = cronField{name: "month", min: 1, max: 12, names: map[string]int{
+		"farvardin": 1, "ordibehesht": 2, "khordad": 3, "tir": 4, "mordad": 5, "shahrivar": 6,
+		"mehr": 7, "aban": 8, "azar": 9, "dey": 10, "bahman": 11, "esfand": 12,
+	}}
+	cronWeekday = cronField{name: "day of week", max: 6, names: map[string]int{
+		"sat": 0, "sun": 1, "mon": 2, "tue": 3, "wed": 4, "thu": 5, "fri": 6,
+	}}
+)
+
+func init() {
+	for i, name := range PersianMonths {
+		cronMonth.names[name] = i + 1
+	}
+	// Fields are separated by spaces, so the two-word day names are also
+	// accepted with a zero-width non-joiner or run together
+	for i, name := range PersianDays {
+		cronWeekday.names[strings.ReplaceAll(name, " ", "\u200c")] = i
+		cronWeekday.names[strings.ReplaceAll(name, " ", "")] = i
+	}
+}
+
+// ParseCron parses a cron expression of five fields, minute hour
+// day-of-month month day-of-week, or of six with a leading second field.
+// The day of month and month are Jalali: months run from 1 (Farvardin) to 12
+// (Esfand) and can be written with their Persian names, such as فروردین, or
+// in Latin letters, such as Farvardin. Days of the week run from 0 (Saturday)
+// to 6 (Friday) and can be written as شنبه or Sat. L in the day of month
+// field is the last day of the month.
+//
+// Fields accept *, lists, ranges and steps, such as 1-10/2; ? is the same as
+// * in the day fields. As in Vixie cron, a day matches if it matches either
+// of the day fields when both are restricted. Next and Prev use the leap
+// rule and the location of p.
+func (p *PersianDate) ParseCron(expr string) (*Cron, error) {
+	fields := strings.Fields(expr)
+	if len(fields) == 5 {
+		fields = append([]string{"0"}, fields...)
+	}
+	if len(fields) != 6 {
+		return nil, &CronError{Field: "expression", Value: expr, Reason: "expected 5 or 6 fields"}
+	}
+
+	loc, err := p.loadLocation()
+	if err != nil && !errors.Is(err, ErrBuiltinLocation) {
+		return nil, err
+	}
+	c := &Cron{p: p, loc: loc}
+	if c.second, err = cronSecond.parse(fields[0]); err != nil {
+		return nil, err
+	}
+	if c.minute, err = cronMinute.parse(fields[1]); err != nil {
+		return nil, err
+	}
+	if c.hour, err = cronHour.parse(fields[2]); err != nil {
+		return nil, err
+	}
+
+	var days []string
+	for _, part := range strings.Split(fields[3], ",") {
+		if strings.EqualFold(part, "L") {
+			c.lastDay = true
+		} else {
+			days = append(days, part)
+		}
+	}
+	if len(days) > 0 {
+		if c.day, err = cronDay.parse(strings.Join(days, ",")); err != nil {
+			return nil, err
+		}
+	}
+	c.anyDay = fields[3] == "*" || fields[3] == "?"
+	if c.month, err = cronMonth.parse(fields[4]); err != nil {
+		return nil, err
+	}
+	if c.weekday, err = cronWeekday.parse(fields[5]); err != nil {
+		return nil, err
+	}
+	c.anyWeekday = fields[5] == "*" || fields[5] == "?"
+
+	// A day of month past the end of every chosen month never matches
+	if c.anyWeekday && !c.lastDay {
+		longest := 30
+		if c.month&0b1111110 != 0 {
+			longest = 31
+		}
+		if bits.TrailingZeros64(c.day) > longest {
+			return nil, &CronError{Field: cronDay.name, Value: fields[3], Reason: "no chosen month has that many days"}
+		}
+	}
+	return c, nil
+}
+
+// parse returns the values of the field s as a bit set
+func (f cronField) parse(s string) (uint64, error) {
+	var set uint64
+	for _, part := range strings.Split(s, ",") {
+		invalid := &CronError{Field: f.name, Value: part}
+
+		span, stepText, hasStep := strings.Cut(part, "/")
+		step := 1
+		if hasStep {
+			var err error
+			if step, err = strconv.Atoi(stepText); err != nil || step < 1 {
+				invalid.Reason = "invalid step"
+				return 0, invalid
+			}
+		}
+
+		first, last := f.min, f.max
+		if span != "*" && span != "?" {
+			low, high, isRange := strings.Cut(span, "-")
+			var ok bool
+			if first, ok = f.value(low); !ok {
+				invalid.Reason = "unknown value"
+				return 0, invalid
+			}
+			if isRange {
+				if last, ok = f.value(high); !ok {
+					invalid.Reason = "unknown value"
+					return 0, invalid
+				}
+			} else if !hasStep {
+				last = first
+			}
+		} else if span == "?" && f.name != cronDay.name && f.name != cronWeekday.name {
+			invalid.Reason = "? is only allowed in the day fields"
+			return 0, invalid
+		}
+		if first < f.min || last > f.max || first > last {
+			invalid.Reason = "out of range " + strconv.Itoa(f.min) + "-" + strconv.Itoa(f.max)
+			return 0, invalid
+		}
+		for v := first; v <= last; v += step {
+			set |= 1 << v
+		}
+	}
+	return set, nil
+}
+
+// value parses a number or a name of the field
+func (f cronField) value(s string) (int, bool) {
+	if n, err := strconv.Atoi(s); err == nil {
+		return n, true
+	}
+	n, ok := f.names[strings.ToLower(s)]
+	return n, ok
+}
+
+// Location returns the location Next and Prev read the fields in
+func (c *Cron) Location() *time.Location {
+	return c.loc
+}
+
+// Next returns the first time after t that matches c, in the location of c.
+// It returns the zero Time if nothing matches in the next twelve years.
+// Wall clock times skipped by a daylight saving change never match.
+func (c *Cron) Next(t time.Time) time.Time {
+	return c.search(t, 1)
+}
+
+// Prev returns the last time before t that matches c, in the location of c.
+// It returns the zero Time if nothing matches in the last twelve years.
+func (c *Cron) Prev(t time.Time) time.Time {
+	return c.search(t, -1)
+}
+
+// search walks the days from the day of t forward or backward, as dir is 1
+// or -1, and returns the first matching time strictly past t
+func (c *Cron) search(t time.Time, dir int) time.Time {
+	t = t.In(c.loc)
+	start := int(JDNFromTime(t))
+	for i := 0; i <= cronHorizon; i++ {
+		jdn := start + dir*i
+		d, err := c.p.julianDayToJalali(jdn)
+		if err != nil {
+			return time.Time{}
+		}
+		if !c.matchesDay(d, jdn) {
+			continue
+		}
+		if at, ok := c.timeOn(JDN(jdn).ToGregorian(), t, dir, i == 0); ok {
+			return at
+		}
+	}
+	return time.Time{}
+}
+
+// matchesDay reports whether the Jalali day d, whose Julian day is jdn,
+// matches the month and day fields
+func (c *Cron) matchesDay(d JalaliDate, jdn int) bool {
+	if c.month&(1<<d.Month) == 0 {
+		return false
+	}
+	day := c.day&(1<<d.Day) != 0 || (c.lastDay && d.Day == c.p.JalaliMonthLength(d.Year, d.Month))
+	weekday := c.weekday&(1<<JDN(jdn).Weekday()) != 0
+	switch {
+	case c.anyDay:
+		return weekday
+	case c.anyWeekday:
+		return day
+	}
+	return day || weekday
+}
+
+// timeOn returns the first time of day on g matching c in the direction dir,
+// past t when g is the day of t
+func (c *Cron) timeOn(g GregorianDate, t time.Time, dir int, sameDay bool) (time.Time, bool) {
+	th, tm, _ := t.Clock()
+	hours, hoursEnd := walk(23, dir)
+	minutes, minutesEnd := walk(59, dir)
+	seconds, secondsEnd := walk(59, dir)
+	for h := hours; h != hoursEnd; h += dir {
+		if c.hour&(1<<h) == 0 || (sameDay && dir*(h-th) < 0) {
+			continue
+		}
+		for m := minutes; m != minutesEnd; m += dir {
+			if c.minute&(1<<m) == 0 || (sameDay && h == th && dir*(m-tm) < 0) {
+				continue
+			}
+			for s := seconds; s != secondsEnd; s += dir {
+				if c.second&(1<<s) == 0 {
+					continue
+				}
+				at := time.Date(g.Year, time.Month(g.Month), g.Day, h, m, s, 0, c.loc)
+				if at.Hour() != h || at.Minute() != m {
+					// Skipped by a daylight saving change
+					continue
+				}
+				if (dir > 0 && at.After(t)) || (dir < 0 && at.Before(t)) {
+					return at, true
+				}
+			}
+		}
+	}
+	return time.Time{}, false
+}
+
+// walk returns where to start and stop walking the values 0 to max in the
+// direction dir
+func walk(max, dir int) (int, int) {
+	if dir > 0 {
+		return 0, max + 1
+	}
+	return max, -1
+}
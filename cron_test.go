@@ -0,0 +1,126 @@
+package persiandate_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	persiandate "github.com/NothingMotion/PersianDate"
+)
+
+func TestCronNext(t *testing.T) {
+	tehran := persiandate.BuiltinLocation("Asia/Tehran")
+	pd := persiandate.New("", persiandate.WithLocation(tehran))
+
+	tests := []struct {
+		expr     string
+		from     time.Time
+		expected time.Time
+	}{
+		{"0 9 1 فروردین *", time.Date(2025, 3, 1, 0, 0, 0, 0, tehran), time.Date(2025, 3, 21, 9, 0, 0, 0, tehran)},
+		{"0 9 1 Farvardin *", time.Date(2025, 3, 21, 9, 0, 0, 0, tehran), time.Date(2026, 3, 21, 9, 0, 0, 0, tehran)},
+		// 1403-12-30 is the last day of the leap year 1403
+		{"0 23 L * *", time.Date(2025, 3, 19, 23, 30, 0, 0, tehran), time.Date(2025, 3, 20, 23, 0, 0, 0, tehran)},
+		{"0 23 L * *", time.Date(2025, 3, 20, 23, 0, 0, 0, tehran), time.Date(2025, 4, 20, 23, 0, 0, 0, tehran)},
+		{"0 23 L اسفند ?", time.Date(2025, 3, 21, 0, 0, 0, 0, tehran), time.Date(2026, 3, 20, 23, 0, 0, 0, tehran)},
+		// 1404-01-01 is a Friday and weekday 0 is Saturday
+		{"0 9 * * 0", time.Date(2025, 3, 21, 12, 0, 0, 0, tehran), time.Date(2025, 3, 22, 9, 0, 0, 0, tehran)},
+		{"0 9 * * شنبه", time.Date(2025, 3, 21, 12, 0, 0, 0, tehran), time.Date(2025, 3, 22, 9, 0, 0, 0, tehran)},
+		{"0 9 * * sun-tue", time.Date(2025, 3, 22, 12, 0, 0, 0, tehran), time.Date(2025, 3, 23, 9, 0, 0, 0, tehran)},
+		{"0 9 * * پنج‌شنبه", time.Date(2025, 3, 21, 12, 0, 0, 0, tehran), time.Date(2025, 3, 27, 9, 0, 0, 0, tehran)},
+		// The day fields are ORed when both are restricted
+		{"0 0 13 * جمعه", time.Date(2025, 3, 22, 0, 0, 0, 0, tehran), time.Date(2025, 3, 28, 0, 0, 0, 0, tehran)},
+		{"0 0 13 * جمعه", time.Date(2025, 3, 28, 0, 0, 0, 0, tehran), time.Date(2025, 4, 2, 0, 0, 0, 0, tehran)},
+		// Six fields with seconds
+		{"30 0 12 * * *", time.Date(2025, 3, 21, 12, 0, 30, 0, tehran), time.Date(2025, 3, 22, 12, 0, 30, 0, tehran)},
+		{"*/20 * * * * *", time.Date(2025, 3, 21, 12, 0, 40, 500, tehran), time.Date(2025, 3, 21, 12, 1, 0, 0, tehran)},
+		{"0 */6 1-10/3 * *", time.Date(2025, 3, 21, 18, 0, 0, 0, tehran), time.Date(2025, 3, 24, 0, 0, 0, 0, tehran)},
+		// 00:30 on 2 Farvardin did not exist while Iran observed daylight saving time
+		{"30 0 2 1 *", time.Date(2021, 1, 1, 0, 0, 0, 0, tehran), time.Date(2023, 3, 22, 0, 30, 0, 0, tehran)},
+	}
+
+	for _, test := range tests {
+		c, err := pd.ParseCron(test.expr)
+		if err != nil {
+			t.Errorf("ParseCron(%q) returned error: %v", test.expr, err)
+			continue
+		}
+		if got := c.Next(test.from); !got.Equal(test.expected) {
+			t.Errorf("%q Next(%v) = %v, expected %v", test.expr, test.from, got, test.expected)
+		}
+		// Nothing matches between from and the next match
+		if prev := c.Prev(test.expected); !prev.IsZero() && (prev.After(test.from) || !c.Next(prev).Equal(test.expected)) {
+			t.Errorf("%q Prev(%v) = %v, expected a match no later than %v", test.expr, test.expected, prev, test.from)
+		}
+	}
+}
+
+func TestCronPrev(t *testing.T) {
+	tehran := persiandate.BuiltinLocation("Asia/Tehran")
+	pd := persiandate.New("", persiandate.WithLocation(tehran))
+
+	tests := []struct {
+		expr     string
+		from     time.Time
+		expected time.Time
+	}{
+		{"0 9 1 1 *", time.Date(2025, 3, 21, 9, 0, 0, 0, tehran), time.Date(2024, 3, 20, 9, 0, 0, 0, tehran)},
+		{"0 23 L * *", time.Date(2025, 4, 20, 22, 0, 0, 0, tehran), time.Date(2025, 3, 20, 23, 0, 0, 0, tehran)},
+		{"15 10 * * *", time.Date(2025, 3, 21, 10, 15, 0, 1, tehran), time.Date(2025, 3, 21, 10, 15, 0, 0, tehran)},
+		{"0 0 30 12 *", time.Date(2025, 3, 1, 0, 0, 0, 0, tehran), time.Date(2021, 3, 20, 0, 0, 0, 0, tehran)},
+	}
+
+	for _, test := range tests {
+		c, err := pd.ParseCron(test.expr)
+		if err != nil {
+			t.Errorf("ParseCron(%q) returned error: %v", test.expr, err)
+			continue
+		}
+		if got := c.Prev(test.from); !got.Equal(test.expected) {
+			t.Errorf("%q Prev(%v) = %v, expected %v", test.expr, test.from, got, test.expected)
+		}
+	}
+}
+
+func TestCronLocation(t *testing.T) {
+	kabul := persiandate.BuiltinLocation("Asia/Kabul")
+	c, err := persiandate.New("", persiandate.WithLocation(kabul)).ParseCron("0 9 1 1 *")
+	if err != nil {
+		t.Fatalf("ParseCron returned error: %v", err)
+	}
+	next := c.Next(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	if c.Location() != kabul || next.Location() != kabul || !next.Equal(time.Date(2025, 3, 21, 4, 30, 0, 0, time.UTC)) {
+		t.Errorf("Next in Kabul = %v, expected 1404-01-01 09:00 +0430", next)
+	}
+}
+
+func TestParseCronErrors(t *testing.T) {
+	pd := persiandate.New("")
+	exprs := []string{
+		"* * *",
+		"* * * * * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * 32 * *",
+		"* * * 13 *",
+		"* * * * 7",
+		"* * * Farvardinx *",
+		"* * * * */0",
+		"* * 5-1 * *",
+		"? * * * *",
+		"0 0 31 مهر-اسفند *",
+	}
+	for _, expr := range exprs {
+		_, err := pd.ParseCron(expr)
+		var cronErr *persiandate.CronError
+		if !errors.Is(err, persiandate.ErrInvalidCron) || !errors.As(err, &cronErr) {
+			t.Errorf("ParseCron(%q) error = %v, expected a *CronError", expr, err)
+		}
+	}
+
+	// A day that only some months have is allowed
+	if _, err := pd.ParseCron("0 0 31 * *"); err != nil {
+		t.Errorf("ParseCron(\"0 0 31 * *\") returned error: %v", err)
+	}
+}
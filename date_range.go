@@ -0,0 +1,194 @@
+package persiandate
+
+// DateRange is a closed range of Jalali days: Start and End are both in it,
+// so a range whose Start equals its End is one day long. Create ranges with
+// Range, which checks the days under the leap rule of the PersianDate, and
+// treat them as values; every method returns a new range.
+//
+// The zero DateRange is empty: it contains no day, and its Start and End are
+// the zero JalaliDate.
+type DateRange struct {
+	p     *PersianDate
+	start JDN
+	after JDN // the day after End, so that start == after is empty
+}
+
+// Range returns the days from start to end inclusive. The clock fields of
+// start and end are ignored.
+func (p *PersianDate) Range(start, end JalaliDate) DateRange {
+	r, err := p.RangeE(start, end)
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+// RangeE is like Range but returns an error instead of panicking. It returns
+// ErrInvalidRange if end is before start.
+func (p *PersianDate) RangeE(start, end JalaliDate) (DateRange, error) {
+	first, err := p.jalaliToJulianDay(start.Year, start.Month, start.Day)
+	if err != nil {
+		return DateRange{}, err
+	}
+	last, err := p.jalaliToJulianDay(end.Year, end.Month, end.Day)
+	if err != nil {
+		return DateRange{}, err
+	}
+	if last < first {
+		return DateRange{}, ErrInvalidRange
+	}
+	return DateRange{p: p, start: JDN(first), after: JDN(last) + 1}, nil
+}
+
+// Start returns the first day of r, or the zero JalaliDate if r is empty
+func (r DateRange) Start() JalaliDate {
+	if r.IsEmpty() {
+		return JalaliDate{}
+	}
+	return r.date(r.start)
+}
+
+// End returns the last day of r, or the zero JalaliDate if r is empty
+func (r DateRange) End() JalaliDate {
+	if r.IsEmpty() {
+		return JalaliDate{}
+	}
+	return r.date(r.end())
+}
+
+// String returns r as an ISO 8601 interval, "1403-12-01/1404-01-10", or
+// "empty" if r is empty
+func (r DateRange) String() string {
+	if r.IsEmpty() {
+		return "empty"
+	}
+	return r.Start().String() + "/" + r.End().String()
+}
+
+// Len returns the number of days in r, counting both ends
+func (r DateRange) Len() int {
+	return r.after.Sub(r.start)
+}
+
+// IsEmpty reports whether r has no days, which is only true of the zero
+// DateRange
+func (r DateRange) IsEmpty() bool {
+	return r.after <= r.start
+}
+
+// Contains reports whether the day of d is in r
+func (r DateRange) Contains(d JalaliDate) bool {
+	jdn, err := r.calendar().jalaliToJulianDay(d.Year, d.Month, d.Day)
+	return err == nil && r.start <= JDN(jdn) && JDN(jdn) < r.after
+}
+
+// Overlaps reports whether r and o have at least one day in common
+func (r DateRange) Overlaps(o DateRange) bool {
+	return r.start < o.after && o.start < r.after
+}
+
+// Intersect returns the days in both r and o. It returns false if they do
+// not overlap.
+func (r DateRange) Intersect(o DateRange) (DateRange, bool) {
+	if !r.Overlaps(o) {
+		return DateRange{}, false
+	}
+	return r.with(max(int(r.start), int(o.start)), min(int(r.end()), int(o.end()))), true
+}
+
+// Union returns the days in r or o as one range. It returns false if there
+// are days between them that neither contains; ranges that only touch, one
+// ending the day before the other starts, are joined. The union with an
+// empty range is the other range.
+func (r DateRange) Union(o DateRange) (DateRange, bool) {
+	switch {
+	case o.IsEmpty():
+		return r, true
+	case r.IsEmpty():
+		return o, true
+	case r.start > o.after || o.start > r.after:
+		return DateRange{}, false
+	}
+	return r.with(min(int(r.start), int(o.start)), max(int(r.end()), int(o.end()))), true
+}
+
+// Subtract returns the days of r that are not in o, as zero, one or two
+// ranges in order
+func (r DateRange) Subtract(o DateRange) []DateRange {
+	if r.IsEmpty() {
+		return nil
+	}
+	if !r.Overlaps(o) {
+		return []DateRange{r}
+	}
+	var pieces []DateRange
+	if r.start < o.start {
+		pieces = append(pieces, r.with(int(r.start), int(o.start)-1))
+	}
+	if o.after < r.after {
+		pieces = append(pieces, r.with(int(o.after), int(r.end())))
+	}
+	return pieces
+}
+
+// Split cuts r at the boundaries of unit, so every piece lies in a single
+// Saturday to Friday week, Jalali month, season or year. The first and last
+// pieces are shorter when r does not start or end on a boundary. An empty
+// range has no pieces.
+func (r DateRange) Split(unit Unit) []DateRange {
+	pieces, err := r.SplitE(unit)
+	if err != nil {
+		panic(err)
+	}
+	return pieces
+}
+
+// SplitE is like Split but returns an error instead of panicking
+func (r DateRange) SplitE(unit Unit) ([]DateRange, error) {
+	p := r.calendar()
+	var pieces []DateRange
+	for day := r.start; day < r.after; {
+		d, err := p.julianDayToJalali(int(day))
+		if err != nil {
+			return nil, err
+		}
+		_, end, err := p.boundsOf(d, unit)
+		if err != nil {
+			return nil, err
+		}
+		last, err := p.jalaliToJulianDay(end.Year, end.Month, end.Day)
+		if err != nil {
+			return nil, err
+		}
+		last = min(last, int(r.end()))
+		pieces = append(pieces, r.with(int(day), last))
+		day = JDN(last + 1)
+	}
+	return pieces, nil
+}
+
+// calendar returns the PersianDate r was created with, or the default one
+// for the zero DateRange
+func (r DateRange) calendar() *PersianDate {
+	if r.p == nil {
+		return calendar
+	}
+	return r.p
+}
+
+func (r DateRange) with(start, end int) DateRange {
+	return DateRange{p: r.p, start: JDN(start), after: JDN(end) + 1}
+}
+
+// end returns the last day of r, which must not be empty
+func (r DateRange) end() JDN {
+	return r.after - 1
+}
+
+func (r DateRange) date(jdn JDN) JalaliDate {
+	d, err := r.calendar().julianDayToJalali(int(jdn))
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
@@ -0,0 +1,169 @@
+package persiandate_test
+
+import (
+	"errors"
+	"testing"
+
+	persiandate "github.com/NothingMotion/PersianDate"
+)
+
+// ranges formats rs as their String values for comparison
+func ranges(rs []persiandate.DateRange) []string {
+	s := make([]string, len(rs))
+	for i, r := range rs {
+		s[i] = r.String()
+	}
+	return s
+}
+
+func TestDateRange(t *testing.T) {
+	pd := persiandate.New("")
+	r := pd.Range(jalali(1403, 12, 1), jalali(1404, 1, 10))
+
+	if r.String() != "1403-12-01/1404-01-10" || r.Len() != 40 {
+		t.Errorf("Range = %v with %d days, expected 1403-12-01/1404-01-10 with 40", r, r.Len())
+	}
+	if one := pd.Range(jalali(1403, 5, 5), jalali(1403, 5, 5)); one.Len() != 1 {
+		t.Errorf("one day range Len() = %d, expected 1", one.Len())
+	}
+	for _, d := range []persiandate.JalaliDate{jalali(1403, 12, 1), jalali(1403, 12, 30), jalali(1404, 1, 10)} {
+		if !r.Contains(d) {
+			t.Errorf("%v should contain %v", r, d)
+		}
+	}
+	for _, d := range []persiandate.JalaliDate{jalali(1403, 11, 30), jalali(1404, 1, 11), jalali(1402, 12, 30)} {
+		if r.Contains(d) {
+			t.Errorf("%v should not contain %v", r, d)
+		}
+	}
+
+	if _, err := pd.RangeE(jalali(1404, 1, 2), jalali(1404, 1, 1)); !errors.Is(err, persiandate.ErrInvalidRange) {
+		t.Errorf("RangeE with end before start error = %v, expected ErrInvalidRange", err)
+	}
+	if _, err := pd.RangeE(jalali(1402, 12, 30), jalali(1404, 1, 1)); !errors.Is(err, persiandate.ErrInvalidDay) {
+		t.Errorf("RangeE(1402-12-30, ...) error = %v, expected ErrInvalidDay", err)
+	}
+}
+
+func TestZeroDateRange(t *testing.T) {
+	var empty persiandate.DateRange
+	if !empty.IsEmpty() || empty.Len() != 0 || empty.String() != "empty" {
+		t.Errorf("zero DateRange IsEmpty() = %v, Len() = %d, String() = %q, expected an empty range",
+			empty.IsEmpty(), empty.Len(), empty.String())
+	}
+	if empty.Start() != (persiandate.JalaliDate{}) || empty.End() != (persiandate.JalaliDate{}) {
+		t.Errorf("zero DateRange Start() = %v, End() = %v, expected zero dates", empty.Start(), empty.End())
+	}
+	if empty.Contains(jalali(1403, 1, 1)) || len(empty.Split(persiandate.UnitMonth)) != 0 || len(empty.Subtract(empty)) != 0 {
+		t.Errorf("zero DateRange should contain no days and have no pieces")
+	}
+
+	r := persiandate.New("").Range(jalali(1403, 1, 1), jalali(1403, 1, 20))
+	if r.IsEmpty() {
+		t.Errorf("%v should not be empty", r)
+	}
+	if r.Overlaps(empty) || empty.Overlaps(r) {
+		t.Errorf("%v should not overlap the empty range", r)
+	}
+	if _, ok := r.Intersect(empty); ok {
+		t.Errorf("Intersect with the empty range should report false")
+	}
+	if union, ok := empty.Union(r); !ok || union != r {
+		t.Errorf("Union of the empty range and %v = %v, %v, expected %v", r, union, ok, r)
+	}
+	if pieces := r.Subtract(empty); len(pieces) != 1 || pieces[0] != r {
+		t.Errorf("%v minus the empty range = %v", r, pieces)
+	}
+}
+
+func TestDateRangeSetOperations(t *testing.T) {
+	pd := persiandate.New("")
+	a := pd.Range(jalali(1403, 1, 1), jalali(1403, 1, 20))
+	b := pd.Range(jalali(1403, 1, 10), jalali(1403, 2, 5))
+	c := pd.Range(jalali(1403, 1, 21), jalali(1403, 1, 25))
+	d := pd.Range(jalali(1403, 3, 1), jalali(1403, 3, 5))
+
+	if !a.Overlaps(b) || a.Overlaps(c) || !b.Overlaps(c) {
+		t.Errorf("Overlaps: a/b %v, a/c %v, b/c %v", a.Overlaps(b), a.Overlaps(c), b.Overlaps(c))
+	}
+	if got, ok := a.Intersect(b); !ok || got.String() != "1403-01-10/1403-01-20" {
+		t.Errorf("a.Intersect(b) = %v, %v", got, ok)
+	}
+	if got, ok := a.Intersect(c); ok {
+		t.Errorf("a.Intersect(c) = %v, expected no overlap", got)
+	}
+	if got, ok := a.Union(b); !ok || got.String() != "1403-01-01/1403-02-05" {
+		t.Errorf("a.Union(b) = %v, %v", got, ok)
+	}
+	if got, ok := c.Union(a); !ok || got.String() != "1403-01-01/1403-01-25" {
+		t.Errorf("adjacent c.Union(a) = %v, %v", got, ok)
+	}
+	if got, ok := a.Union(d); ok {
+		t.Errorf("a.Union(d) = %v, expected a gap", got)
+	}
+
+	tests := []struct {
+		r, o     persiandate.DateRange
+		expected []string
+	}{
+		{b, c, []string{"1403-01-10/1403-01-20", "1403-01-26/1403-02-05"}},
+		{a, b, []string{"1403-01-01/1403-01-09"}},
+		{b, a, []string{"1403-01-21/1403-02-05"}},
+		{c, b, []string{}},
+		{a, d, []string{"1403-01-01/1403-01-20"}},
+	}
+	for _, test := range tests {
+		got := ranges(test.r.Subtract(test.o))
+		if len(got) != len(test.expected) {
+			t.Errorf("%v.Subtract(%v) = %v, expected %v", test.r, test.o, got, test.expected)
+			continue
+		}
+		for i := range got {
+			if got[i] != test.expected[i] {
+				t.Errorf("%v.Subtract(%v) = %v, expected %v", test.r, test.o, got, test.expected)
+				break
+			}
+		}
+	}
+}
+
+func TestDateRangeSplit(t *testing.T) {
+	pd := persiandate.New("")
+
+	tests := []struct {
+		start, end persiandate.JalaliDate
+		unit       persiandate.Unit
+		expected   []string
+		lengths    []int
+	}{
+		{jalali(1403, 12, 1), jalali(1404, 1, 10), persiandate.UnitMonth,
+			[]string{"1403-12-01/1403-12-30", "1404-01-01/1404-01-10"}, []int{30, 10}},
+		{jalali(1402, 12, 1), jalali(1403, 1, 10), persiandate.UnitMonth,
+			[]string{"1402-12-01/1402-12-29", "1403-01-01/1403-01-10"}, []int{29, 10}},
+		{jalali(1403, 6, 15), jalali(1403, 10, 2), persiandate.UnitSeason,
+			[]string{"1403-06-15/1403-06-31", "1403-07-01/1403-09-30", "1403-10-01/1403-10-02"}, []int{17, 90, 2}},
+		{jalali(1403, 12, 20), jalali(1404, 1, 9), persiandate.UnitWeek,
+			[]string{"1403-12-20/1403-12-24", "1403-12-25/1404-01-01", "1404-01-02/1404-01-08", "1404-01-09/1404-01-09"}, []int{5, 7, 7, 1}},
+		{jalali(1402, 6, 1), jalali(1403, 2, 1), persiandate.UnitYear,
+			[]string{"1402-06-01/1402-12-29", "1403-01-01/1403-02-01"}, []int{210, 32}},
+	}
+
+	for _, test := range tests {
+		pieces := pd.Range(test.start, test.end).Split(test.unit)
+		got := ranges(pieces)
+		if len(got) != len(test.expected) {
+			t.Errorf("Split(%v) of %v to %v = %v, expected %v", test.unit, test.start, test.end, got, test.expected)
+			continue
+		}
+		for i := range got {
+			if got[i] != test.expected[i] || pieces[i].Len() != test.lengths[i] {
+				t.Errorf("Split(%v) piece %d = %v with %d days, expected %s with %d",
+					test.unit, i, got[i], pieces[i].Len(), test.expected[i], test.lengths[i])
+			}
+		}
+	}
+
+	if _, err := pd.Range(jalali(1403, 1, 1), jalali(1403, 1, 2)).SplitE(persiandate.Unit(9)); !errors.Is(err, persiandate.ErrInvalidUnit) {
+		t.Errorf("SplitE(Unit(9)) error = %v, expected ErrInvalidUnit", err)
+	}
+}
@@ -0,0 +1,91 @@
+package persiandate_test
+
+import (
+	"testing"
+	"time"
+
+	persiandate "github.com/NothingMotion/PersianDate"
+)
+
+func TestFromTimeKeepsClock(t *testing.T) {
+	pd := persiandate.New("")
+
+	source := time.Date(2023, 9, 6, 17, 42, 9, 123456789, time.UTC) // 1402-06-15
+	date := pd.FromTime(source)
+
+	if h, m, s := date.Clock(); h != 17 || m != 42 || s != 9 {
+		t.Errorf("Clock() = %d:%d:%d, expected 17:42:9", h, m, s)
+	}
+	if date.GetHour() != 17 || date.GetMinute() != 42 || date.GetSecond() != 9 {
+		t.Errorf("GetHour/GetMinute/GetSecond = %d/%d/%d, expected 17/42/9",
+			date.GetHour(), date.GetMinute(), date.GetSecond())
+	}
+
+	dt := date.DateTime()
+	if dt.Nanosecond != 123456789 || dt.Location != time.UTC {
+		t.Errorf("DateTime() = %v, expected nanoseconds and UTC to be kept", dt)
+	}
+}
+
+func TestDateTimeRoundTrip(t *testing.T) {
+	pd := persiandate.New("")
+
+	tehran := time.FixedZone("+0330", 3*3600+1800)
+	kabul := time.FixedZone("+0430", 4*3600+1800)
+	times := []time.Time{
+		time.Date(2024, 3, 19, 23, 59, 59, 999999999, tehran), // last moment of 1402
+		time.Date(2024, 3, 20, 0, 0, 0, 1, kabul),
+		time.Date(1979, 2, 11, 12, 30, 0, 0, time.UTC),
+		time.Date(2025, 3, 29, 6, 7, 8, 9, time.Local),
+	}
+
+	for _, source := range times {
+		dt := pd.DateTimeOf(source)
+		back := pd.DateTimeToTime(dt)
+		if !back.Equal(source) || back.Location() != source.Location() || back.Nanosecond() != source.Nanosecond() {
+			t.Errorf("DateTimeToTime(DateTimeOf(%v)) = %v", source, back)
+		}
+
+		back = pd.DateTimeToTime(pd.FromTime(source).DateTime())
+		if !back.Equal(source) || back.Location() != source.Location() {
+			t.Errorf("FromTime(%v) round trip = %v", source, back)
+		}
+	}
+
+	dt := pd.DateTimeOf(time.Date(2024, 3, 19, 23, 59, 59, 500000000, tehran))
+	if dt.Offset != 3*3600+1800 {
+		t.Errorf("DateTimeOf(+0330).Offset = %d, expected %d", dt.Offset, 3*3600+1800)
+	}
+	if s := dt.String(); s != "1402-12-29 23:59:59.5 +0330" {
+		t.Errorf("String() = %s, expected 1402-12-29 23:59:59.5 +0330", s)
+	}
+}
+
+// TestDateTimeDSTOverlap converts both instants of a clock repeated when
+// daylight saving time ended in Tehran at midnight on 21 September 2021
+func TestDateTimeDSTOverlap(t *testing.T) {
+	tehran := persiandate.BuiltinLocation("Asia/Tehran")
+	pd := persiandate.New("")
+
+	first := time.Date(2021, 9, 21, 19, 15, 0, 0, time.UTC).In(tehran) // 23:45 +0430
+	second := first.Add(time.Hour)                                     // 23:45 +0330
+	if first.Hour() != 23 || first.Minute() != 45 || second.Hour() != 23 || second.Minute() != 45 {
+		t.Fatalf("expected both %v and %v to read 23:45", first, second)
+	}
+
+	for _, source := range []time.Time{first, second} {
+		dt := pd.DateTimeOf(source)
+		if dt.JalaliDate != (persiandate.JalaliDate{Date: persiandate.Date{Year: 1400, Month: 6, Day: 30, Hour: 23, Minute: 45}}) {
+			t.Errorf("DateTimeOf(%v) = %v, expected 1400-06-30 23:45", source, dt)
+		}
+		if back := pd.DateTimeToTime(dt); !back.Equal(source) {
+			t.Errorf("DateTimeToTime(DateTimeOf(%v)) = %v", source, back)
+		}
+		if back := pd.DateTimeToTime(pd.FromTime(source).DateTime()); !back.Equal(source) {
+			t.Errorf("FromTime(%v) round trip = %v", source, back)
+		}
+		if back := persiandate.TimeOf(source).DateTime(); !pd.DateTimeToTime(back).Equal(source) {
+			t.Errorf("TimeOf(%v).DateTime() round trip = %v", source, pd.DateTimeToTime(back))
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package persiandate_test
+
+import (
+	"os"
+	"os/exec"
+	"testing"
+
+	persiandate "github.com/NothingMotion/PersianDate"
+)
+
+// TestDayArithmeticInLocalZone checks Add, Sub and the weekday of every day
+// from 1365 to 1405 against Julian day numbers. Iran, like the zones used in
+// TestDayArithmeticUnderTZ, changed its clocks at midnight in those years.
+func TestDayArithmeticInLocalZone(t *testing.T) {
+	pd := persiandate.New("l")
+	start := jalali(1365, 1, 1)
+	startJDN, _ := persiandate.JDNFromJalali(1365, 1, 1)
+	end, _ := persiandate.JDNFromJalali(1406, 1, 1)
+
+	previous := start
+	for i := 1; startJDN+persiandate.JDN(i) < end; i++ {
+		jdn := startJDN + persiandate.JDN(i)
+		expected, _ := jdn.ToJalali()
+
+		if got := pd.Add(start, i).Date(); got != expected {
+			t.Fatalf("Add(%v, %d) = %v, expected %v in %v", start, i, got, expected, os.Getenv("TZ"))
+		}
+		if got := pd.Add(previous, 1).Date(); got != expected {
+			t.Fatalf("Add(%v, 1) = %v, expected %v in %v", previous, got, expected, os.Getenv("TZ"))
+		}
+		if got := pd.Sub(expected, 1).Date(); got != previous {
+			t.Fatalf("Sub(%v, 1) = %v, expected %v in %v", expected, got, previous, os.Getenv("TZ"))
+		}
+
+		date := pd.Add(start, i)
+		if got := date.GetWeekDay(); got != jdn.Weekday() {
+			t.Fatalf("GetWeekDay() of %v = %d, expected %d in %v", expected, got, jdn.Weekday(), os.Getenv("TZ"))
+		}
+		if got := pd.Format(expected); got != persiandate.PersianDays[jdn.Weekday()] {
+			t.Fatalf("Format(%v, \"l\") = %s, expected %s in %v", expected, got, persiandate.PersianDays[jdn.Weekday()], os.Getenv("TZ"))
+		}
+		previous = expected
+	}
+}
+
+// TestDayArithmeticUnderTZ runs TestDayArithmeticInLocalZone again with TZ
+// set to zones whose daylight saving time starts or ends at midnight
+func TestDayArithmeticUnderTZ(t *testing.T) {
+	if os.Getenv("PERSIANDATE_TZ_CHILD") != "" {
+		t.Skip("already running under a TZ value")
+	}
+	zones := []string{"UTC", "Asia/Tehran", "America/Sao_Paulo", "America/Havana", "America/Santiago", "Asia/Beirut", "Pacific/Kiritimati", "Etc/GMT+12"}
+	for _, zone := range zones {
+		cmd := exec.Command(os.Args[0], "-test.run=^TestDayArithmeticInLocalZone$", "-test.count=1")
+		cmd.Env = append(os.Environ(), "TZ="+zone, "PERSIANDATE_TZ_CHILD=1")
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Errorf("TZ=%s: %v\n%s", zone, err, out)
+		}
+	}
+}
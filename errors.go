@@ -0,0 +1,182 @@
+package persiandate
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Sentinel errors returned (wrapped in a *DateError) by the E variants of the
+// conversion and arithmetic methods. Use errors.Is to test for them.
+var (
+	ErrYearOutOfRange = errors.New("year out of range")
+	ErrInvalidMonth   = errors.New("invalid month")
+	ErrInvalidDay     = errors.New("invalid day")
+	ErrInvalidSeason  = errors.New("invalid season")
+	ErrInvalidUnit    = errors.New("invalid unit")
+	ErrInvalidQuarter = errors.New("invalid quarter")
+)
+
+// DateError describes a date field that could not be converted.
+type DateError struct {
+	Calendar string // "Jalali" or "Gregorian"
+	Field    string // "year", "month", "day", "season", "unit" or "quarter"
+	Value    int
+	Err      error
+}
+
+func (e *DateError) Error() string {
+	return fmt.Sprintf("invalid %s %s %d: %v", e.Calendar, e.Field, e.Value, e.Err)
+}
+
+func (e *DateError) Unwrap() error {
+	return e.Err
+}
+
+func yearOutOfRange(cal string, year int) error {
+	return &DateError{Calendar: cal, Field: "year", Value: year, Err: ErrYearOutOfRange}
+}
+
+func invalidMonth(cal string, month int) error {
+	return &DateError{Calendar: cal, Field: "month", Value: month, Err: ErrInvalidMonth}
+}
+
+func invalidDay(cal string, day int) error {
+	return &DateError{Calendar: cal, Field: "day", Value: day, Err: ErrInvalidDay}
+}
+
+func invalidSeason(season int) error {
+	return &DateError{Calendar: "Jalali", Field: "season", Value: season, Err: ErrInvalidSeason}
+}
+
+func invalidUnit(unit int) error {
+	return &DateError{Calendar: "Jalali", Field: "unit", Value: unit, Err: ErrInvalidUnit}
+}
+
+func invalidQuarter(quarter int) error {
+	return &DateError{Calendar: "Jalali", Field: "quarter", Value: quarter, Err: ErrInvalidQuarter}
+}
+
+// ErrInvalidRange is returned by RangeE for a range that ends before it starts
+var ErrInvalidRange = errors.New("range ends before it starts")
+
+// ErrInvalidStep is reported by the iterator of Every for a step that is zero
+// or has a negative field
+var ErrInvalidStep = errors.New("step does not move forward")
+
+// ErrInvalidRule is matched by a *RuleError
+var ErrInvalidRule = errors.New("invalid recurrence rule")
+
+// RuleError reports a part of a recurrence rule that is malformed, not
+// supported, or not allowed with the other parts.
+type RuleError struct {
+	Part   string // such as "BYMONTHDAY=32" or "BYWEEKNO"
+	Reason string
+}
+
+func (e *RuleError) Error() string {
+	return fmt.Sprintf("%v: %s: %s", ErrInvalidRule, e.Part, e.Reason)
+}
+
+func (e *RuleError) Unwrap() error {
+	return ErrInvalidRule
+}
+
+// ErrInvalidCron is matched by a *CronError
+var ErrInvalidCron = errors.New("invalid cron expression")
+
+// CronError reports a field of a cron expression that could not be parsed
+type CronError struct {
+	Field  string // "second", "minute", "hour", "day of month", "month", "day of week" or "expression"
+	Value  string
+	Reason string
+}
+
+func (e *CronError) Error() string {
+	return fmt.Sprintf("%v: %s %q: %s", ErrInvalidCron, e.Field, e.Value, e.Reason)
+}
+
+func (e *CronError) Unwrap() error {
+	return ErrInvalidCron
+}
+
+// ErrBeforeBirth is returned by AgeE for a date before the date of birth
+var ErrBeforeBirth = errors.New("date is before birth")
+
+// ErrNilLocation is returned by NewTimeE when it is given a nil location
+var ErrNilLocation = errors.New("missing location")
+
+// ErrBuiltinLocation is matched by a *LocationError, which is returned when the
+// system zoneinfo was unavailable and the built-in offset table was used.
+var ErrBuiltinLocation = errors.New("using built-in offset table")
+
+// LocationError reports that a location could not be loaded from the system
+// zoneinfo. The location returned alongside it is still usable.
+type LocationError struct {
+	Name string
+	Err  error // error returned by time.LoadLocation
+}
+
+func (e *LocationError) Error() string {
+	return fmt.Sprintf("loading location %s: %v: %v", e.Name, e.Err, ErrBuiltinLocation)
+}
+
+func (e *LocationError) Unwrap() error {
+	return e.Err
+}
+
+func (e *LocationError) Is(target error) bool {
+	return target == ErrBuiltinLocation
+}
+
+// ErrUnsupportedType is returned for inputs of a type Sort, Min, Max and
+// Filter do not know how to convert.
+var ErrUnsupportedType = errors.New("unsupported date type")
+
+// RejectedInput is an input that could not be placed on the calendar
+type RejectedInput struct {
+	Index int // position in the input
+	Value interface{}
+	Err   error
+}
+
+// RejectedError lists every input rejected by Sort, Min, Max or Filter.
+// The accepted inputs are still processed and returned alongside it.
+type RejectedError struct {
+	Inputs []RejectedInput
+}
+
+func (e *RejectedError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d rejected dates", len(e.Inputs))
+	for i, in := range e.Inputs {
+		sep := ", "
+		if i == 0 {
+			sep = ": "
+		}
+		fmt.Fprintf(&b, "%s#%d %v (%v)", sep, in.Index, in.Value, in.Err)
+	}
+	return b.String()
+}
+
+// Unwrap returns the error of every rejected input, so errors.Is can match
+// ErrInvalidDay and the other sentinels.
+func (e *RejectedError) Unwrap() []error {
+	errs := make([]error, len(e.Inputs))
+	for i, in := range e.Inputs {
+		errs[i] = in.Err
+	}
+	return errs
+}
+
+func (e *RejectedError) add(index int, value interface{}, err error) {
+	e.Inputs = append(e.Inputs, RejectedInput{Index: index, Value: value, Err: err})
+}
+
+// err returns e as an error, or nil if nothing was rejected
+func (e *RejectedError) err() error {
+	if len(e.Inputs) == 0 {
+		return nil
+	}
+	return e
+}
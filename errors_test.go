@@ -0,0 +1,92 @@
+package persiandate_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	persiandate "github.com/NothingMotion/PersianDate"
+)
+
+func TestConversionErrors(t *testing.T) {
+	pd := persiandate.New("")
+
+	tests := []struct {
+		year, month, day int
+		expected         error
+		field            string
+	}{
+		{3500, 1, 1, persiandate.ErrYearOutOfRange, "year"},
+		{-100, 1, 1, persiandate.ErrYearOutOfRange, "year"},
+		{1402, 13, 1, persiandate.ErrInvalidMonth, "month"},
+		{1402, 0, 1, persiandate.ErrInvalidMonth, "month"},
+		{1402, 12, 30, persiandate.ErrInvalidDay, "day"}, // 1402 is not a leap year
+		{1402, 7, 31, persiandate.ErrInvalidDay, "day"},
+	}
+
+	for _, test := range tests {
+		_, err := pd.ToGregorianE(test.year, test.month, test.day)
+		if !errors.Is(err, test.expected) {
+			t.Errorf("ToGregorianE(%d, %d, %d) error = %v, expected %v",
+				test.year, test.month, test.day, err, test.expected)
+			continue
+		}
+
+		var dateErr *persiandate.DateError
+		if !errors.As(err, &dateErr) {
+			t.Errorf("ToGregorianE(%d, %d, %d) error %T is not a *DateError", test.year, test.month, test.day, err)
+			continue
+		}
+		if dateErr.Field != test.field {
+			t.Errorf("ToGregorianE(%d, %d, %d) field = %s, expected %s",
+				test.year, test.month, test.day, dateErr.Field, test.field)
+		}
+	}
+}
+
+func TestErrorVariants(t *testing.T) {
+	pd := persiandate.New("")
+
+	valid := persiandate.JalaliDate{Date: persiandate.Date{Year: 1402, Month: 1, Day: 1}}
+	invalid := persiandate.JalaliDate{Date: persiandate.Date{Year: 1402, Month: 12, Day: 30}}
+
+	if _, err := pd.ToTimeE(1402, 12, 30, 0, 0, 0, 0); !errors.Is(err, persiandate.ErrInvalidDay) {
+		t.Errorf("ToTimeE error = %v, expected ErrInvalidDay", err)
+	}
+	if _, err := pd.DifferenceE(valid, invalid); !errors.Is(err, persiandate.ErrInvalidDay) {
+		t.Errorf("DifferenceE error = %v, expected ErrInvalidDay", err)
+	}
+	if _, err := pd.AddE(invalid, 1); !errors.Is(err, persiandate.ErrInvalidDay) {
+		t.Errorf("AddE error = %v, expected ErrInvalidDay", err)
+	}
+	if _, err := pd.SortE(valid, invalid); !errors.Is(err, persiandate.ErrInvalidDay) {
+		t.Errorf("SortE error = %v, expected ErrInvalidDay", err)
+	}
+	if _, err := pd.MinE([]persiandate.JalaliDate{valid, invalid}); !errors.Is(err, persiandate.ErrInvalidDay) {
+		t.Errorf("MinE error = %v, expected ErrInvalidDay", err)
+	}
+	if _, err := pd.ToJalaliE(2023, 2, 29); !errors.Is(err, persiandate.ErrInvalidDay) {
+		t.Errorf("ToJalaliE error = %v, expected ErrInvalidDay", err)
+	}
+	if _, err := pd.FromTimeE(time.Date(4000, 1, 1, 0, 0, 0, 0, time.UTC)); !errors.Is(err, persiandate.ErrYearOutOfRange) {
+		t.Errorf("FromTimeE error = %v, expected ErrYearOutOfRange", err)
+	}
+
+	days, err := pd.DifferenceE(valid, persiandate.JalaliDate{Date: persiandate.Date{Year: 1403, Month: 1, Day: 1}})
+	if err != nil || days != 365 {
+		t.Errorf("DifferenceE(1402-01-01, 1403-01-01) = %d, %v, expected 365, nil", days, err)
+	}
+}
+
+func TestPanickingVariants(t *testing.T) {
+	pd := persiandate.New("")
+
+	defer func() {
+		r := recover()
+		err, ok := r.(error)
+		if !ok || !errors.Is(err, persiandate.ErrYearOutOfRange) {
+			t.Errorf("ToGregorian(3500, 1, 1) panicked with %v, expected ErrYearOutOfRange", r)
+		}
+	}()
+	pd.ToGregorian(3500, 1, 1)
+}
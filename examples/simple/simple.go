@@ -37,18 +37,19 @@ func main() {
 	////////////////////////////////////////////
 
 	// Converting jalali date object to time object
-	pd.Now().ToTime(pd.GetYear(), pd.GetMonth(), pd.GetDay(), 0, 0, 0, 0)
+	today := pd.Now() // methods return a new value and never modify pd
+	today.ToTime(today.GetYear(), today.GetMonth(), today.GetDay(), 0, 0, 0, 0)
 
 	////////////////////////////////////////////
 
 	// Gets day index of week from 0 (Saturday) to 6 (Friday)
-	wd := pd.GetWeekDay()
+	wd := today.GetWeekDay()
 	// Gets name of the day
 	pd.GetDayName(wd)
 	////////////////////////////////////////////
 
 	// Gets day of year
-	pd.GetYearDay()
+	today.GetYearDay()
 
 	////////////////////////////////////////////
 }
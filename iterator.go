@@ -0,0 +1,167 @@
+package persiandate
+
+// Iterator walks the Jalali calendar from one date to another without
+// changing the current date of the PersianDate it came from. Use it like a
+// bufio.Scanner:
+//
+//	it := pd.Months(from, to)
+//	for it.Next() {
+//		fmt.Println(it.Date(), it.End().Day)
+//	}
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+//
+// Next does not allocate.
+type Iterator struct {
+	p        *PersianDate
+	unit     Unit // UnitDay, UnitWeek or UnitMonth
+	every    bool
+	step     Period
+	from, to JalaliDate
+	last     int64 // Julian day of to, or its seconds for Every
+
+	n          int // dates yielded so far
+	date, end  JalaliDate
+	err        error
+	done, init bool
+}
+
+// Days returns an iterator over every day from from to to inclusive. The
+// clock fields of the dates are ignored.
+func (p *PersianDate) Days(from, to JalaliDate) *Iterator {
+	return &Iterator{p: p, unit: UnitDay, from: from, to: to}
+}
+
+// Weeks returns an iterator over the Saturday of every week with a day
+// between from and to inclusive, so the first Saturday may be before from
+func (p *PersianDate) Weeks(from, to JalaliDate) *Iterator {
+	return &Iterator{p: p, unit: UnitWeek, from: from, to: to}
+}
+
+// Months returns an iterator over the first day of every month with a day
+// between from and to inclusive. End returns the last day of the month.
+func (p *PersianDate) Months(from, to JalaliDate) *Iterator {
+	return &Iterator{p: p, unit: UnitMonth, from: from, to: to}
+}
+
+// Every returns an iterator over from, from plus step, from plus two steps
+// and so on while they are not after to, clock fields included. Every date
+// is counted from from, so a month step from 31 Shahrivar yields the last
+// day of each of the next months without drifting to the 30th. The step
+// must move forward; otherwise Err returns ErrInvalidStep.
+func (p *PersianDate) Every(from, to JalaliDate, step Period) *Iterator {
+	return &Iterator{p: p, every: true, step: step, from: from, to: to}
+}
+
+// Next moves to the next date and reports whether there is one
+func (it *Iterator) Next() bool {
+	if it.done {
+		return false
+	}
+	if !it.init {
+		it.init = true
+		if it.err = it.start(); it.err != nil || it.done {
+			it.done = true
+			return false
+		}
+	}
+
+	var err error
+	var date JalaliDate
+	var position int64
+	if !it.every {
+		date, err = it.nextUnit()
+		if err == nil {
+			var jdn int
+			jdn, err = it.p.jalaliToJulianDay(date.Year, date.Month, date.Day)
+			position = int64(jdn)
+		}
+	} else {
+		n := it.n
+		date, err = it.p.addPeriod(it.from, Period{
+			Years: n * it.step.Years, Months: n * it.step.Months, Days: n * it.step.Days,
+			Hours: n * it.step.Hours, Minutes: n * it.step.Minutes, Seconds: n * it.step.Seconds,
+		})
+		if err == nil {
+			position, err = it.p.secondsOf(date)
+		}
+	}
+	if err != nil || position > it.last {
+		// A date past the end of the calendar is after to as well
+		it.done = true
+		return false
+	}
+
+	it.date, it.end = date, date
+	if !it.every {
+		if _, it.end, err = it.p.boundsOf(date, it.unit); err != nil {
+			it.err, it.done = err, true
+			return false
+		}
+	}
+	it.n++
+	return true
+}
+
+// Date returns the date Next moved to
+func (it *Iterator) Date() JalaliDate {
+	return it.date
+}
+
+// End returns the last day of the week or month starting at Date, which may
+// be after to. For Days and Every it returns Date.
+func (it *Iterator) End() JalaliDate {
+	return it.end
+}
+
+// Err returns the error that stopped the iterator, if any
+func (it *Iterator) Err() error {
+	return it.err
+}
+
+// start checks the bounds and step and finds the position of to. It sets
+// done when to is before from, so that no date is yielded.
+func (it *Iterator) start() error {
+	if err := it.p.validateJalaliDate(it.from); err != nil {
+		return err
+	}
+	if err := it.p.validateJalaliDate(it.to); err != nil {
+		return err
+	}
+	if !it.every {
+		first, err := it.p.jalaliToJulianDay(it.from.Year, it.from.Month, it.from.Day)
+		if err != nil {
+			return err
+		}
+		last, err := it.p.jalaliToJulianDay(it.to.Year, it.to.Month, it.to.Day)
+		it.last, it.done = int64(last), last < first
+		return err
+	}
+	s := it.step
+	if s.IsZero() || s.Years < 0 || s.Months < 0 || s.Days < 0 || s.Hours < 0 || s.Minutes < 0 || s.Seconds < 0 {
+		return ErrInvalidStep
+	}
+	first, err := it.p.secondsOf(it.from)
+	if err != nil {
+		return err
+	}
+	last, err := it.p.secondsOf(it.to)
+	it.last, it.done = last, last < first
+	return err
+}
+
+// nextUnit returns the first day of the next day, week or month
+func (it *Iterator) nextUnit() (JalaliDate, error) {
+	if it.n == 0 {
+		start, _, err := it.p.boundsOf(it.from, it.unit)
+		return start, err
+	}
+	switch it.unit {
+	case UnitWeek:
+		return it.p.addDays(it.date, 7)
+	case UnitMonth:
+		return it.p.addMonths(it.date, 1, Clamp)
+	}
+	return it.p.addDays(it.date, 1)
+}
@@ -0,0 +1,150 @@
+package persiandate_test
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	persiandate "github.com/NothingMotion/PersianDate"
+)
+
+// collect returns the dates and ends yielded by it as strings
+func collect(t *testing.T, it *persiandate.Iterator) (dates, ends []string) {
+	t.Helper()
+	for it.Next() {
+		dates = append(dates, it.Date().String())
+		ends = append(ends, it.End().String())
+	}
+	if err := it.Err(); err != nil {
+		t.Fatalf("iterator returned error: %v", err)
+	}
+	return dates, ends
+}
+
+func TestDays(t *testing.T) {
+	pd := persiandate.New("").Add(jalali(1400, 1, 1), 0)
+	dates, _ := collect(t, pd.Days(jalali(1403, 12, 28), jalali(1404, 1, 2)))
+	expected := []string{"1403-12-28", "1403-12-29", "1403-12-30", "1404-01-01", "1404-01-02"}
+	if !slices.Equal(dates, expected) {
+		t.Errorf("Days(1403-12-28, 1404-01-02) = %v, expected %v", dates, expected)
+	}
+	if pd.GetYear() != 1400 || pd.GetMonth() != 1 || pd.GetDay() != 1 {
+		t.Errorf("Days changed the current date to %d-%02d-%02d", pd.GetYear(), pd.GetMonth(), pd.GetDay())
+	}
+
+	if dates, _ := collect(t, pd.Days(jalali(1403, 2, 1), jalali(1403, 1, 1))); len(dates) != 0 {
+		t.Errorf("Days with to before from = %v, expected none", dates)
+	}
+}
+
+func TestWeeks(t *testing.T) {
+	pd := persiandate.New("")
+	dates, ends := collect(t, pd.Weeks(jalali(1404, 1, 1), jalali(1404, 1, 16)))
+	if expected := []string{"1403-12-25", "1404-01-02", "1404-01-09", "1404-01-16"}; !slices.Equal(dates, expected) {
+		t.Errorf("Weeks(1404-01-01, 1404-01-16) = %v, expected %v", dates, expected)
+	}
+	if expected := []string{"1404-01-01", "1404-01-08", "1404-01-15", "1404-01-22"}; !slices.Equal(ends, expected) {
+		t.Errorf("Weeks(1404-01-01, 1404-01-16) ends = %v, expected %v", ends, expected)
+	}
+}
+
+func TestMonths(t *testing.T) {
+	pd := persiandate.New("")
+	dates, ends := collect(t, pd.Months(jalali(1402, 11, 15), jalali(1403, 1, 1)))
+	if expected := []string{"1402-11-01", "1402-12-01", "1403-01-01"}; !slices.Equal(dates, expected) {
+		t.Errorf("Months(1402-11-15, 1403-01-01) = %v, expected %v", dates, expected)
+	}
+	if expected := []string{"1402-11-30", "1402-12-29", "1403-01-31"}; !slices.Equal(ends, expected) {
+		t.Errorf("Months(1402-11-15, 1403-01-01) ends = %v, expected %v", ends, expected)
+	}
+
+	it := pd.Months(jalali(1403, 1, 1), jalali(1403, 12, 30))
+	lengths := 0
+	for it.Next() {
+		lengths += it.End().Day
+	}
+	if lengths != 366 {
+		t.Errorf("the months of 1403 have %d days, expected 366", lengths)
+	}
+}
+
+func TestEvery(t *testing.T) {
+	pd := persiandate.New("")
+
+	from := jalali(1403, 6, 31)
+	from.Hour = 9
+	to := jalali(1403, 12, 30)
+	to.Hour = 8
+	dates, _ := collect(t, pd.Every(from, to, persiandate.Period{Months: 2}))
+	if expected := []string{"1403-06-31", "1403-08-30", "1403-10-30"}; !slices.Equal(dates, expected) {
+		t.Errorf("Every 2 months from 1403-06-31 = %v, expected %v", dates, expected)
+	}
+
+	it := pd.Every(from, jalali(1403, 7, 2), persiandate.Period{Hours: 12})
+	var hours []int
+	for it.Next() {
+		hours = append(hours, it.Date().Day*100+it.Date().Hour)
+	}
+	if expected := []int{3109, 3121, 109, 121}; !slices.Equal(hours, expected) {
+		t.Errorf("Every 12 hours = %v, expected %v", hours, expected)
+	}
+
+	for _, step := range []persiandate.Period{{}, {Days: -1}} {
+		it := pd.Every(from, to, step)
+		if it.Next() || !errors.Is(it.Err(), persiandate.ErrInvalidStep) {
+			t.Errorf("Every with step %v error = %v, expected ErrInvalidStep", step, it.Err())
+		}
+	}
+
+	it = pd.Days(jalali(1402, 12, 30), to)
+	if it.Next() || !errors.Is(it.Err(), persiandate.ErrInvalidDay) {
+		t.Errorf("Days from 1402-12-30 error = %v, expected ErrInvalidDay", it.Err())
+	}
+}
+
+func TestIteratorsReversedSpan(t *testing.T) {
+	pd := persiandate.New("")
+	later := jalali(1404, 1, 6)
+	later.Hour = 9
+	earlier := jalali(1404, 1, 3)
+
+	iterators := map[string]func(from, to persiandate.JalaliDate) *persiandate.Iterator{
+		"Days":   pd.Days,
+		"Weeks":  pd.Weeks,
+		"Months": pd.Months,
+		"Every": func(from, to persiandate.JalaliDate) *persiandate.Iterator {
+			return pd.Every(from, to, persiandate.Period{Hours: 1})
+		},
+	}
+	for name, iterate := range iterators {
+		// The two days are in the same week and month, which must not be yielded
+		if dates, _ := collect(t, iterate(later, earlier)); len(dates) != 0 {
+			t.Errorf("%s(1404-01-06, 1404-01-03) = %v, expected nothing", name, dates)
+		}
+		if dates, _ := collect(t, iterate(later, later)); len(dates) != 1 {
+			t.Errorf("%s(1404-01-06, 1404-01-06) = %v, expected one date", name, dates)
+		}
+	}
+
+	// Every compares the clock fields too
+	sameDay := later
+	sameDay.Hour = 8
+	if dates, _ := collect(t, pd.Every(later, sameDay, persiandate.Period{Minutes: 1})); len(dates) != 0 {
+		t.Errorf("Every from 09:00 to 08:00 = %v, expected nothing", dates)
+	}
+}
+
+func TestIteratorNextDoesNotAllocate(t *testing.T) {
+	pd := persiandate.New("")
+	iterators := map[string]*persiandate.Iterator{
+		"Days":   pd.Days(jalali(1300, 1, 1), jalali(1500, 1, 1)),
+		"Weeks":  pd.Weeks(jalali(1300, 1, 1), jalali(1500, 1, 1)),
+		"Months": pd.Months(jalali(1300, 1, 1), jalali(1500, 1, 1)),
+		"Every":  pd.Every(jalali(1300, 1, 1), jalali(1500, 1, 1), persiandate.Period{Days: 3}),
+	}
+	for name, it := range iterators {
+		if allocs := testing.AllocsPerRun(100, func() { it.Next() }); allocs != 0 {
+			t.Errorf("%s Next allocates %v times per step", name, allocs)
+		}
+	}
+}
@@ -0,0 +1,132 @@
+package persiandate
+
+import (
+	"math"
+	"time"
+)
+
+// JDN is a Julian Day Number: the number of whole days since 1 January 4713 BC
+// in the proleptic Julian calendar. 1 Farvardin 1403 (20 March 2024) is 2460390.
+// JDN is an int, so days are added and subtracted with ordinary arithmetic.
+type JDN int
+
+// JDNFromJalali returns the Julian Day Number of a Jalali date
+func JDNFromJalali(jy, jm, jd int) (JDN, error) {
+	jdn, err := calendar.jalaliToJulianDay(jy, jm, jd)
+	return JDN(jdn), err
+}
+
+// JDNFromGregorian returns the Julian Day Number of a Gregorian date
+func JDNFromGregorian(gy, gm, gd int) (JDN, error) {
+	if err := calendar.validateGregorianDate(gy, gm, gd); err != nil {
+		return 0, err
+	}
+	return JDN(calendar.gregorianToJulianDay(gy, gm, gd)), nil
+}
+
+// JDNFromTime returns the Julian Day Number of the date of t in its location
+func JDNFromTime(t time.Time) JDN {
+	y, m, d := t.Date()
+	return JDN(calendar.gregorianToJulianDay(y, int(m), d))
+}
+
+// JDN returns j itself, so day numbers can be used with Sort, Min, Max and Filter
+func (j JDN) JDN() (JDN, error) {
+	return j, nil
+}
+
+// ToJalali returns the Jalali date of j
+func (j JDN) ToJalali() (JalaliDate, error) {
+	return calendar.julianDayToJalali(int(j))
+}
+
+// ToGregorian returns the Gregorian date of j
+func (j JDN) ToGregorian() GregorianDate {
+	return calendar.julianDayToGregorian(int(j))
+}
+
+// Time returns midnight at the start of the day j in loc
+func (j JDN) Time(loc *time.Location) time.Time {
+	g := j.ToGregorian()
+	return time.Date(g.Year, time.Month(g.Month), g.Day, 0, 0, 0, 0, loc)
+}
+
+// Weekday returns the day of week of j, from 0 (Saturday) to 6 (Friday)
+func (j JDN) Weekday() int {
+	return floorMod(int(j)+2, 7)
+}
+
+// AddDays returns j+days
+func (j JDN) AddDays(days int) JDN {
+	return j + JDN(days)
+}
+
+// Sub returns the number of days from k to j
+func (j JDN) Sub(k JDN) int {
+	return int(j - k)
+}
+
+// JulianDate returns the astronomical Julian Date of midnight at the start of j.
+// Julian Dates begin at noon, so it is j-0.5.
+func (j JDN) JulianDate() JulianDate {
+	return JulianDate(float64(j) - 0.5)
+}
+
+// unixEpochJulianDate is the Julian Date of 1970-01-01 00:00 UTC
+const unixEpochJulianDate = 2440587.5
+
+// mjdOffset is the difference between a Julian Date and a Modified Julian Date
+const mjdOffset = 2400000.5
+
+// unixEpochMJD is the Modified Julian Date of 1970-01-01 00:00 UTC
+const unixEpochMJD = unixEpochJulianDate - mjdOffset
+
+// JulianDate is an astronomical Julian Date: days and fractions of a day since
+// noon UTC on 1 January 4713 BC in the proleptic Julian calendar. A float64
+// keeps a present day Julian Date to within about 50 microseconds.
+type JulianDate float64
+
+// JulianDateOf returns the Julian Date of the instant t
+func JulianDateOf(t time.Time) JulianDate {
+	sec := float64(t.Unix()) + float64(t.Nanosecond())/1e9
+	return JulianDate(unixEpochJulianDate + sec/86400)
+}
+
+// Time returns the instant of jd in UTC, rounded to the microsecond
+func (jd JulianDate) Time() time.Time {
+	us := math.Round((float64(jd) - unixEpochJulianDate) * 86400e6)
+	return time.UnixMicro(int64(us)).UTC()
+}
+
+// JDN returns the Julian Day Number of the UTC day containing jd
+func (jd JulianDate) JDN() JDN {
+	return JDN(math.Floor(float64(jd) + 0.5))
+}
+
+// MJD returns jd as a Modified Julian Date
+func (jd JulianDate) MJD() ModifiedJulianDate {
+	return ModifiedJulianDate(float64(jd) - mjdOffset)
+}
+
+// ModifiedJulianDate is a Julian Date minus 2400000.5, which counts days from
+// midnight UTC on 17 November 1858.
+type ModifiedJulianDate float64
+
+// MJDOf returns the Modified Julian Date of the instant t. It is computed
+// directly rather than from the Julian Date, so present day values keep
+// microsecond precision.
+func MJDOf(t time.Time) ModifiedJulianDate {
+	sec := float64(t.Unix()) + float64(t.Nanosecond())/1e9
+	return ModifiedJulianDate(unixEpochMJD + sec/86400)
+}
+
+// JulianDate returns m as a Julian Date
+func (m ModifiedJulianDate) JulianDate() JulianDate {
+	return JulianDate(float64(m) + mjdOffset)
+}
+
+// Time returns the instant of m in UTC, rounded to the microsecond
+func (m ModifiedJulianDate) Time() time.Time {
+	us := math.Round((float64(m) - unixEpochMJD) * 86400e6)
+	return time.UnixMicro(int64(us)).UTC()
+}
@@ -0,0 +1,97 @@
+package persiandate_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	persiandate "github.com/NothingMotion/PersianDate"
+)
+
+func TestJDNConversions(t *testing.T) {
+	tests := []struct {
+		jdn        persiandate.JDN
+		jalali     string
+		gregorian  string
+		weekday    int
+		jalaliDate [3]int
+	}{
+		{2451545, "1378-10-11", "2000-01-01", 0, [3]int{1378, 10, 11}}, // Saturday
+		{2460390, "1403-01-01", "2024-03-20", 4, [3]int{1403, 1, 1}},   // Wednesday
+		{2443916, "1357-11-22", "1979-02-11", 1, [3]int{1357, 11, 22}}, // Sunday
+		{2460389, "1402-12-29", "2024-03-19", 3, [3]int{1402, 12, 29}}, // Tuesday
+	}
+
+	for _, test := range tests {
+		fromJalali, err := persiandate.JDNFromJalali(test.jalaliDate[0], test.jalaliDate[1], test.jalaliDate[2])
+		if err != nil || fromJalali != test.jdn {
+			t.Errorf("JDNFromJalali(%s) = %d, %v, expected %d", test.jalali, fromJalali, err, test.jdn)
+		}
+
+		g := test.jdn.ToGregorian()
+		fromGregorian, err := persiandate.JDNFromGregorian(g.Year, g.Month, g.Day)
+		if g.String() != test.gregorian || err != nil || fromGregorian != test.jdn {
+			t.Errorf("JDN(%d).ToGregorian() = %v, JDNFromGregorian = %d, %v", test.jdn, g, fromGregorian, err)
+		}
+
+		j, err := test.jdn.ToJalali()
+		if err != nil || j.String() != test.jalali {
+			t.Errorf("JDN(%d).ToJalali() = %v, %v, expected %s", test.jdn, j, err, test.jalali)
+		}
+
+		if wd := test.jdn.Weekday(); wd != test.weekday {
+			t.Errorf("JDN(%d).Weekday() = %d, expected %d", test.jdn, wd, test.weekday)
+		}
+
+		midnight := test.jdn.Time(time.UTC)
+		if midnight.Format("2006-01-02 15:04") != test.gregorian+" 00:00" || persiandate.JDNFromTime(midnight) != test.jdn {
+			t.Errorf("JDN(%d).Time(UTC) = %v", test.jdn, midnight)
+		}
+	}
+
+	nowruz := persiandate.JDN(2460390)
+	if nowruz.AddDays(-1) != 2460389 || nowruz+365 != 2460755 || nowruz.Sub(2460024) != 366 {
+		t.Errorf("JDN arithmetic returned the wrong result")
+	}
+
+	if _, err := persiandate.JDNFromGregorian(2023, 2, 29); err == nil {
+		t.Errorf("JDNFromGregorian(2023, 2, 29) should return an error")
+	}
+}
+
+func TestJulianDate(t *testing.T) {
+	// J2000.0 epoch: 2000-01-01 12:00 TT is JD 2451545.0 (ignoring TT-UTC here)
+	noon := time.Date(2000, 1, 1, 12, 0, 0, 0, time.UTC)
+	jd := persiandate.JulianDateOf(noon)
+	if jd != 2451545.0 {
+		t.Errorf("JulianDateOf(2000-01-01 12:00) = %f, expected 2451545.0", float64(jd))
+	}
+	if jd.JDN() != 2451545 {
+		t.Errorf("JulianDate(%f).JDN() = %d, expected 2451545", float64(jd), jd.JDN())
+	}
+
+	midnight := time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+	if jd := persiandate.JulianDateOf(midnight); jd != 2451544.5 || jd.JDN() != 2451545 {
+		t.Errorf("JulianDateOf(2000-01-01 00:00) = %f, JDN %d", float64(jd), jd.JDN())
+	}
+	if persiandate.JDN(2451545).JulianDate() != 2451544.5 {
+		t.Errorf("JDN(2451545).JulianDate() = %f, expected 2451544.5", float64(persiandate.JDN(2451545).JulianDate()))
+	}
+
+	// MJD 0 is 1858-11-17 00:00 UTC
+	if mjd := persiandate.MJDOf(time.Date(1858, 11, 17, 0, 0, 0, 0, time.UTC)); mjd != 0 {
+		t.Errorf("MJDOf(1858-11-17) = %f, expected 0", float64(mjd))
+	}
+	if mjd := persiandate.MJDOf(time.Date(2024, 3, 20, 6, 0, 0, 0, time.UTC)); math.Abs(float64(mjd)-60389.25) > 1e-9 {
+		t.Errorf("MJDOf(2024-03-20 06:00) = %f, expected 60389.25", float64(mjd))
+	}
+
+	at := time.Date(2024, 3, 20, 3, 6, 25, 123456000, time.UTC)
+	// A float64 Julian Date only resolves about 40 microseconds today
+	if back := persiandate.JulianDateOf(at).Time(); back.Sub(at).Abs() > 50*time.Microsecond {
+		t.Errorf("JulianDateOf(%v).Time() = %v", at, back)
+	}
+	if back := persiandate.MJDOf(at).Time(); !back.Equal(at) {
+		t.Errorf("MJDOf(%v).Time() = %v", at, back)
+	}
+}
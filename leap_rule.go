@@ -0,0 +1,133 @@
+package persiandate
+
+import "strconv"
+
+// LeapRule decides which Jalali years have 366 days and so where each year
+// starts on the Julian day line. Every conversion, month length and leap year
+// check of a PersianDate uses the rule it was created with, see WithLeapRule.
+//
+// Nowruz(jy+1) - Nowruz(jy) must be 366 exactly when IsLeap(jy) is true.
+type LeapRule interface {
+	// YearRange returns the first and last Jalali year the rule covers
+	YearRange() (first, last int)
+	// Nowruz returns the Julian Day Number of 1 Farvardin of the year jy
+	Nowruz(jy int) JDN
+	// IsLeap reports whether the year jy has 366 days
+	IsLeap(jy int) bool
+}
+
+// BreakTable is the break table of Kazimierz Borkowski's algorithm, which
+// tracks the astronomical calendar closely but only covers the Jalali years
+// -61 to 3177. It is the default rule.
+type BreakTable struct{}
+
+func (BreakTable) String() string { return "BreakTable" }
+
+func (BreakTable) YearRange() (int, int) {
+	return -61, 3177
+}
+
+func (BreakTable) Nowruz(jy int) JDN {
+	r, _ := calendar.jalCal(jy, true)
+	return JDN(calendar.gregorianToJulianDay(r.gy, 3, r.march))
+}
+
+// IsLeap reports whether jy has 366 days. It is false outside the table.
+func (BreakTable) IsLeap(jy int) bool {
+	r, err := calendar.jalCal(jy, false)
+	return err == nil && r.leap == 0
+}
+
+// prolepticYears bounds the years of Cycle33 and Cycle2820 so that their
+// Julian Day Numbers, the seconds counted by Between and the arithmetic of
+// the conversions fit in an int: a million years where int has 32 bits and
+// a hundred billion, close to the range of time.Time, where it has 64.
+const prolepticYears = 1000000 + (strconv.IntSize/64)*(100000000000-1000000)
+
+// Cycle33 is a proleptic calendar in which a year is leap when
+// (25*year+11) mod 33 < 8, the 33-year cycle. It covers the years from
+// -100000000000 to 100000000000, or -1000000 to 1000000 where int has 32
+// bits, including year 0 and negative (astronomical) years. It has
+// the same leap years as BreakTable from 1178 to 1633, so both start every
+// year from 1178 to 1634 on the same day.
+type Cycle33 struct{}
+
+func (Cycle33) String() string { return "Cycle33" }
+
+func (Cycle33) YearRange() (int, int) {
+	return -prolepticYears, prolepticYears
+}
+
+// Nowruz counts the days of the years before jy. floorDiv(8*jy+21, 33) is the
+// number of leap years before jy, offset so that 1 Farvardin 1 is JDN 1948320.
+func (Cycle33) Nowruz(jy int) JDN {
+	return JDN(1948320 + 365*(jy-1) + floorDiv(8*jy+21, 33))
+}
+
+func (Cycle33) IsLeap(jy int) bool {
+	return floorMod(25*jy+11, 33) < 8
+}
+
+// Cycle2820 is Ahmad Birashk's proleptic 2820-year cycle of 683 leap years,
+// with astronomical year numbering. It covers the same years as Cycle33
+// and has the same leap years as BreakTable from 1244 to 1402; from 1403 on
+// it places some leap years one year later than the table.
+type Cycle2820 struct{}
+
+func (Cycle2820) String() string { return "Cycle2820" }
+
+func (Cycle2820) YearRange() (int, int) {
+	return -prolepticYears, prolepticYears
+}
+
+// cycle2820 returns the position of jy in its 2820-year cycle, counted from
+// year 474, and the number of whole cycles before it
+func cycle2820(jy int) (year, cycles int) {
+	return 474 + floorMod(jy-474, 2820), floorDiv(jy-474, 2820)
+}
+
+func (Cycle2820) Nowruz(jy int) JDN {
+	year, cycles := cycle2820(jy)
+	return JDN(1948321 + floorDiv(year*682-110, 2816) + (year-1)*365 + cycles*1029983)
+}
+
+func (Cycle2820) IsLeap(jy int) bool {
+	year, _ := cycle2820(jy)
+	return (year+38)*682%2816 < 682
+}
+
+// leapRule returns the rule of p, BreakTable if none was set
+func (p *PersianDate) leapRule() LeapRule {
+	if p.rule == nil {
+		return BreakTable{}
+	}
+	return p.rule
+}
+
+// LeapRule returns the leap year rule p uses
+func (p *PersianDate) LeapRule() LeapRule {
+	return p.leapRule()
+}
+
+// YearRange returns the first and last Jalali year p accepts
+func (p *PersianDate) YearRange() (first, last int) {
+	return p.leapRule().YearRange()
+}
+
+// CompareLeapRules returns the years from first to last, inclusive, that
+// are leap under one of the rules but not the other. Years outside the range
+// of either rule are skipped.
+func CompareLeapRules(a, b LeapRule, first, last int) []int {
+	aFirst, aLast := a.YearRange()
+	bFirst, bLast := b.YearRange()
+	first = max(max(first, aFirst), bFirst)
+	last = min(min(last, aLast), bLast)
+
+	var years []int
+	for jy := first; jy <= last; jy++ {
+		if a.IsLeap(jy) != b.IsLeap(jy) {
+			years = append(years, jy)
+		}
+	}
+	return years
+}
@@ -0,0 +1,176 @@
+package persiandate_test
+
+import (
+	"errors"
+	"slices"
+	"strconv"
+	"testing"
+	"time"
+
+	persiandate "github.com/NothingMotion/PersianDate"
+)
+
+// prolepticYears is the last year of Cycle33 and Cycle2820: a million where int
+// has 32 bits and a hundred billion where it has 64
+const prolepticYears = 1000000 + strconv.IntSize/64*(100000000000-1000000)
+
+func TestLeapRuleYearRange(t *testing.T) {
+	tests := []struct {
+		rule        persiandate.LeapRule
+		first, last int
+	}{
+		{persiandate.BreakTable{}, -61, 3177},
+		{persiandate.Cycle33{}, -prolepticYears, prolepticYears},
+		{persiandate.Cycle2820{}, -prolepticYears, prolepticYears},
+	}
+
+	for _, test := range tests {
+		pd := persiandate.New("", persiandate.WithLeapRule(test.rule))
+		if first, last := pd.YearRange(); first != test.first || last != test.last {
+			t.Errorf("%v YearRange() = %d, %d, expected %d, %d", test.rule, first, last, test.first, test.last)
+		}
+		for _, year := range []int{test.first, test.last} {
+			if _, err := pd.ToGregorianE(year, 1, 1); err != nil {
+				t.Errorf("%v ToGregorianE(%d, 1, 1) returned error: %v", test.rule, year, err)
+			}
+		}
+		for _, year := range []int{test.first - 1, test.last + 1} {
+			if _, err := pd.ToGregorianE(year, 1, 1); !errors.Is(err, persiandate.ErrYearOutOfRange) {
+				t.Errorf("%v ToGregorianE(%d, 1, 1) error = %v, expected ErrYearOutOfRange", test.rule, year, err)
+			}
+		}
+	}
+
+	if _, ok := persiandate.New("").LeapRule().(persiandate.BreakTable); !ok {
+		t.Errorf("default leap rule should be BreakTable")
+	}
+}
+
+func TestCycle33MatchesBreakTable(t *testing.T) {
+	breakTable := persiandate.New("")
+	cycle33 := persiandate.New("", persiandate.WithLeapRule(persiandate.Cycle33{}))
+
+	// The break table follows the 33-year cycle between its breaks at 1178 and 1635
+	for year := 1178; year <= 1634; year++ {
+		want := breakTable.ToGregorian(year, 1, 1)
+		if got := cycle33.ToGregorian(year, 1, 1); got != want {
+			t.Errorf("Cycle33 1 Farvardin %d = %v, BreakTable %v", year, got, want)
+		}
+	}
+	if breakTable.ToGregorian(1635, 1, 1) == cycle33.ToGregorian(1635, 1, 1) {
+		t.Errorf("Cycle33 and BreakTable should disagree on 1 Farvardin 1635")
+	}
+}
+
+func TestLeapRuleConsistency(t *testing.T) {
+	rules := []persiandate.LeapRule{persiandate.BreakTable{}, persiandate.Cycle33{}, persiandate.Cycle2820{}}
+	for _, rule := range rules {
+		pd := persiandate.New("L", persiandate.WithLeapRule(rule))
+		for year := -61; year < 3177; year++ {
+			leap := rule.IsLeap(year)
+			if length := rule.Nowruz(year+1) - rule.Nowruz(year); (length == 366) != leap || length < 365 || length > 366 {
+				t.Fatalf("%v year %d has %d days, IsLeap = %v", rule, year, length, leap)
+			}
+
+			_, err := pd.ToGregorianE(year, 12, 30)
+			length := pd.JalaliMonthLength(year, 12)
+			if pd.IsLeapYearJalali(year) != leap || (err == nil) != leap || (length == 30) != leap {
+				t.Fatalf("%v year %d: IsLeapYearJalali = %v, 30 Esfand error = %v, Esfand length = %d, expected leap %v",
+					rule, year, pd.IsLeapYearJalali(year), err, length, leap)
+			}
+		}
+	}
+
+	// 1635 is leap in the break table but not in the 33-year cycle
+	pd := persiandate.New("L")
+	nowruz := pd.ToTime(1636, 1, 1, 12, 0, 0, 0)
+	if full := pd.FromTimeFull(nowruz.AddDate(0, 0, -1)); full.Month != 12 || full.Day != 30 {
+		t.Errorf("the day before 1 Farvardin 1636 = %v, expected 1635-12-30", full)
+	}
+	if leap := pd.Format(jalali(1635, 1, 1)); leap != "بله" {
+		t.Errorf("Format(1635-01-01, \"L\") = %s, expected بله", leap)
+	}
+	if persiandate.New("", persiandate.WithLeapRule(persiandate.Cycle33{})).IsLeapYearJalali(1635) {
+		t.Errorf("Cycle33 should not make 1635 a leap year")
+	}
+}
+
+func TestCompareLeapRules(t *testing.T) {
+	got := persiandate.CompareLeapRules(persiandate.BreakTable{}, persiandate.Cycle2820{}, 1380, 1440)
+	if !slices.Equal(got, []int{1403, 1404, 1436, 1437}) {
+		t.Errorf("CompareLeapRules(BreakTable, Cycle2820, 1380, 1440) = %v", got)
+	}
+
+	if got := persiandate.CompareLeapRules(persiandate.BreakTable{}, persiandate.Cycle33{}, 1178, 1633); len(got) != 0 {
+		t.Errorf("CompareLeapRules(BreakTable, Cycle33, 1178, 1633) = %v, expected none", got)
+	}
+	if got := persiandate.CompareLeapRules(persiandate.BreakTable{}, persiandate.Cycle2820{}, 1244, 1402); len(got) != 0 {
+		t.Errorf("CompareLeapRules(BreakTable, Cycle2820, 1244, 1402) = %v, expected none", got)
+	}
+
+	// Years outside the break table are skipped
+	got = persiandate.CompareLeapRules(persiandate.Cycle33{}, persiandate.BreakTable{}, 3170, 4000)
+	for _, year := range got {
+		if year > 3177 {
+			t.Errorf("CompareLeapRules returned %d, outside the break table", year)
+		}
+	}
+}
+
+func TestCycle33Proleptic(t *testing.T) {
+	pd := persiandate.New("", persiandate.WithLeapRule(persiandate.Cycle33{}))
+
+	tests := []struct {
+		jy, jm, jd int
+		gregorian  string
+	}{
+		{1403, 1, 1, "2024-03-20"},
+		{1, 1, 1, "622-03-21"},
+		{-3, 12, 30, "619-03-21"}, // -3 is a leap year in the 33-year cycle
+		{-100, 7, 1, "521-09-23"},
+		{4000, 1, 1, "4621-03-21"},
+	}
+
+	for _, test := range tests {
+		g, err := pd.ToGregorianE(test.jy, test.jm, test.jd)
+		if err != nil || g.String() != test.gregorian {
+			t.Errorf("ToGregorianE(%d, %d, %d) = %v, %v, expected %s", test.jy, test.jm, test.jd, g, err, test.gregorian)
+			continue
+		}
+		back, err := pd.ToJalaliE(g.Year, g.Month, g.Day)
+		if err != nil || back.Date() != (persiandate.JalaliDate{Date: persiandate.Date{Year: test.jy, Month: test.jm, Day: test.jd}}) {
+			t.Errorf("ToJalaliE(%v) = %v, %v", g, back.Date(), err)
+		}
+	}
+
+	// Every day round trips through the Julian day line, far outside the break table
+	for _, start := range []int{-prolepticYears, -1000000, -200, 3150, 999998, prolepticYears - 1} {
+		first := pd.ToGregorian(start, 1, 1)
+		previous := time.Date(first.Year, time.Month(first.Month), first.Day-1, 0, 0, 0, 0, time.UTC)
+		for y := start; y < start+2; y++ {
+			for m := 1; m <= 12; m++ {
+				for d := 1; d <= 31; d++ {
+					g, err := pd.ToGregorianE(y, m, d)
+					if err != nil {
+						continue
+					}
+					at := time.Date(g.Year, time.Month(g.Month), g.Day, 0, 0, 0, 0, time.UTC)
+					if next := previous.AddDate(0, 0, 1); !at.Equal(next) {
+						t.Fatalf("%d-%02d-%02d = %v, expected %v", y, m, d, at, next)
+					}
+					previous = at
+					if dt := pd.DateTimeOf(at); dt.Year != y || dt.Month != m || dt.Day != d {
+						t.Fatalf("DateTimeOf(%v) = %v, expected %d-%02d-%02d", at, dt, y, m, d)
+					}
+				}
+			}
+		}
+	}
+
+	if _, err := persiandate.New("").FromTimeE(time.Date(4000, 1, 1, 0, 0, 0, 0, time.UTC)); !errors.Is(err, persiandate.ErrYearOutOfRange) {
+		t.Errorf("BreakTable FromTimeE(4000-01-01) error = %v, expected ErrYearOutOfRange", err)
+	}
+	if _, err := pd.FromTimeE(time.Date(4000, 1, 1, 0, 0, 0, 0, time.UTC)); err != nil {
+		t.Errorf("Cycle33 FromTimeE(4000-01-01) returned error: %v", err)
+	}
+}
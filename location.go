@@ -0,0 +1,178 @@
+package persiandate
+
+import (
+	"encoding/binary"
+	"sync"
+	"time"
+)
+
+// zoneType is one offset a location has used
+type zoneType struct {
+	name   string
+	offset int // seconds east of UTC
+	isDST  bool
+}
+
+// zoneTransition is the moment a location switched to zones[zone]
+type zoneTransition struct {
+	at   int64 // Unix seconds
+	zone int
+}
+
+var (
+	tehranOnce sync.Once
+	tehranLoc  *time.Location
+	tehranErr  error
+
+	kabulOnce sync.Once
+	kabulLoc  *time.Location
+	kabulErr  error
+
+	builtinOnce     sync.Once
+	builtinTehranTZ *time.Location
+	builtinKabulTZ  *time.Location
+)
+
+// Tehran returns the Asia/Tehran location.
+// If the system zoneinfo cannot be loaded it returns the built-in Iran offset
+// table instead, together with a *LocationError describing why.
+func Tehran() (*time.Location, error) {
+	tehranOnce.Do(func() {
+		tehranLoc, tehranErr = loadLocation("Asia/Tehran")
+	})
+	return tehranLoc, tehranErr
+}
+
+// Kabul returns the Asia/Kabul location.
+// If the system zoneinfo cannot be loaded it returns the built-in Afghanistan
+// offset table instead, together with a *LocationError describing why.
+func Kabul() (*time.Location, error) {
+	kabulOnce.Do(func() {
+		kabulLoc, kabulErr = loadLocation("Asia/Kabul")
+	})
+	return kabulLoc, kabulErr
+}
+
+// BuiltinLocation returns the offset table shipped with the package for
+// "Asia/Tehran" or "Asia/Kabul", or nil for any other name. The Tehran table
+// includes the daylight saving time Iran observed until 1401 (2022).
+func BuiltinLocation(name string) *time.Location {
+	builtinOnce.Do(func() {
+		builtinTehranTZ = buildLocation("Asia/Tehran", tehranZones, tehranTransitions())
+		builtinKabulTZ = buildLocation("Asia/Kabul", kabulZones, kabulTransitions)
+	})
+	switch name {
+	case "Asia/Tehran":
+		return builtinTehranTZ
+	case "Asia/Kabul":
+		return builtinKabulTZ
+	}
+	return nil
+}
+
+func loadLocation(name string) (*time.Location, error) {
+	loc, err := time.LoadLocation(name)
+	if err == nil {
+		return loc, nil
+	}
+	return BuiltinLocation(name), &LocationError{Name: name, Err: err}
+}
+
+var tehranZones = []zoneType{
+	{name: "LMT", offset: 3*3600 + 25*60 + 44},
+	{name: "+0330", offset: 3*3600 + 1800},
+	{name: "+0430", offset: 4*3600 + 1800, isDST: true},
+	{name: "+04", offset: 4 * 3600},
+	{name: "+05", offset: 5 * 3600, isDST: true},
+}
+
+// tehranTransitions lists Iran's offset changes. The irregular changes before
+// 1991 are listed explicitly. From 1370 to 1401 daylight saving time followed
+// the Jalali calendar: it started at 24:00 on 1 Farvardin and ended at 24:00
+// on 30 Shahrivar, except for 1385 and 1386 when it was not observed.
+func tehranTransitions() []zoneTransition {
+	transitions := []zoneTransition{
+		{at: -1090466744, zone: 1}, // 1935-06-13
+		{at: 227820600, zone: 2},   // 1977-03-22
+		{at: 246223800, zone: 3},   // 1977-10-20
+		{at: 259617600, zone: 4},   // 1978-03-25
+		{at: 271108800, zone: 3},   // 1978-08-05
+		{at: 279576000, zone: 1},   // 1978-11-10
+		{at: 296598600, zone: 2},   // 1979-05-27
+		{at: 306531000, zone: 1},   // 1979-09-18
+		{at: 322432200, zone: 2},   // 1980-03-21
+		{at: 338499000, zone: 1},   // 1980-09-22
+		{at: 673216200, zone: 2},   // 1991-05-03
+	}
+	standard := tehranZones[1].offset
+	daylight := tehranZones[2].offset
+	for jy := 1370; jy <= 1401; jy++ {
+		if jy == 1385 || jy == 1386 {
+			continue
+		}
+		if jy > 1370 {
+			transitions = append(transitions, zoneTransition{at: jalaliMidnight(jy, 1, 2, standard), zone: 2})
+		}
+		transitions = append(transitions, zoneTransition{at: jalaliMidnight(jy, 6, 31, daylight), zone: 1})
+	}
+	return transitions
+}
+
+// kabulZones starts at +04; the local mean time used before 1890 is outside
+// the range of the version 1 TZif data built below.
+var kabulZones = []zoneType{
+	{name: "+04", offset: 4 * 3600},
+	{name: "+0430", offset: 4*3600 + 1800},
+}
+
+var kabulTransitions = []zoneTransition{
+	{at: -788932800, zone: 1}, // 1945-01-01
+}
+
+// jalaliMidnight returns the Unix time of 00:00 on the given Jalali date at a
+// fixed offset from UTC
+func jalaliMidnight(jy, jm, jd, offset int) int64 {
+	g := calendar.ToGregorian(jy, jm, jd)
+	return time.Date(g.Year, time.Month(g.Month), g.Day, 0, 0, 0, 0, time.UTC).Unix() - int64(offset)
+}
+
+// buildLocation encodes the zones and transitions as TZif (version 1) data
+// and loads it, which is the only way to build a time.Location with more
+// than one offset.
+func buildLocation(name string, zones []zoneType, transitions []zoneTransition) *time.Location {
+	var abbrs []byte
+	abbrIndex := make([]int, len(zones))
+	for i, z := range zones {
+		abbrIndex[i] = len(abbrs)
+		abbrs = append(abbrs, z.name...)
+		abbrs = append(abbrs, 0)
+	}
+
+	data := []byte("TZif")
+	data = append(data, make([]byte, 16)...) // version 1 and reserved bytes
+	for _, n := range []int{0, 0, 0, len(transitions), len(zones), len(abbrs)} {
+		data = binary.BigEndian.AppendUint32(data, uint32(n))
+	}
+	for _, t := range transitions {
+		data = binary.BigEndian.AppendUint32(data, uint32(int32(t.at)))
+	}
+	for _, t := range transitions {
+		data = append(data, byte(t.zone))
+	}
+	for i, z := range zones {
+		data = binary.BigEndian.AppendUint32(data, uint32(int32(z.offset)))
+		isDST := byte(0)
+		if z.isDST {
+			isDST = 1
+		}
+		data = append(data, isDST, byte(abbrIndex[i]))
+	}
+	data = append(data, abbrs...)
+
+	loc, err := time.LoadLocationFromTZData(name, data)
+	if err != nil {
+		// The tables above are static, so this only happens if they are broken.
+		panic(err)
+	}
+	return loc
+}
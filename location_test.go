@@ -0,0 +1,92 @@
+package persiandate_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	persiandate "github.com/NothingMotion/PersianDate"
+)
+
+func TestBuiltinLocationMatchesZoneinfo(t *testing.T) {
+	for _, name := range []string{"Asia/Tehran", "Asia/Kabul"} {
+		system, err := time.LoadLocation(name)
+		if err != nil {
+			t.Skipf("system zoneinfo for %s unavailable: %v", name, err)
+		}
+		builtin := persiandate.BuiltinLocation(name)
+		if builtin == nil {
+			t.Fatalf("BuiltinLocation(%s) = nil", name)
+		}
+
+		// Compare every 30 minutes from 1902 until well after the last transition.
+		end := time.Date(2040, 1, 1, 0, 0, 0, 0, time.UTC)
+		for at := time.Date(1902, 1, 1, 0, 0, 0, 0, time.UTC); at.Before(end); at = at.Add(30 * time.Minute) {
+			_, want := at.In(system).Zone()
+			_, got := at.In(builtin).Zone()
+			if got != want {
+				t.Errorf("%s offset at %v = %d, expected %d", name, at, got, want)
+				break
+			}
+		}
+	}
+
+	if persiandate.BuiltinLocation("Europe/Paris") != nil {
+		t.Errorf("BuiltinLocation(Europe/Paris) should be nil")
+	}
+}
+
+func TestBuiltinTehranDST(t *testing.T) {
+	tehran := persiandate.BuiltinLocation("Asia/Tehran")
+
+	tests := []struct {
+		at       time.Time
+		expected string
+	}{
+		{time.Date(2022, 3, 21, 20, 29, 59, 0, time.UTC), "1401-01-01 23:59 +0330"},
+		{time.Date(2022, 3, 21, 20, 30, 0, 0, time.UTC), "1401-01-02 01:00 +0430"},
+		{time.Date(2022, 9, 21, 19, 29, 59, 0, time.UTC), "1401-06-30 23:59 +0430"},
+		{time.Date(2022, 9, 21, 19, 30, 0, 0, time.UTC), "1401-06-30 23:00 +0330"},
+		{time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC), "1402-03-11 15:30 +0330"},
+		{time.Date(2007, 6, 1, 12, 0, 0, 0, time.UTC), "1386-03-11 15:30 +0330"},
+	}
+
+	for _, test := range tests {
+		local := persiandate.TimeOf(test.at.In(tehran))
+		got := local.Date().String() + " " + local.Std().Format("15:04 -0700")
+		if got != test.expected {
+			t.Errorf("%v in built-in Tehran = %s, expected %s", test.at, got, test.expected)
+		}
+	}
+}
+
+func TestWithLocation(t *testing.T) {
+	kabul := time.FixedZone("+0430", 4*3600+1800)
+	pd := persiandate.New("", persiandate.WithLocation(kabul))
+	if pd.Location() != kabul {
+		t.Errorf("Location() = %v, expected %v", pd.Location(), kabul)
+	}
+
+	now, err := pd.NowE()
+	if err != nil {
+		t.Errorf("NowE() returned error: %v", err)
+	}
+	if now.DateTime().Location != kabul {
+		t.Errorf("NowE() location = %v, expected %v", now.DateTime().Location, kabul)
+	}
+
+	pd = persiandate.New("")
+	if _, err := pd.NowE(); err != nil && !errors.Is(err, persiandate.ErrBuiltinLocation) {
+		t.Errorf("NowE() returned unexpected error: %v", err)
+	}
+	if pd.Location().String() != "Asia/Tehran" {
+		t.Errorf("default Location() = %v, expected Asia/Tehran", pd.Location())
+	}
+}
+
+func TestLocationError(t *testing.T) {
+	err := error(&persiandate.LocationError{Name: "Asia/Tehran", Err: errors.New("unknown time zone Asia/Tehran")})
+	if !errors.Is(err, persiandate.ErrBuiltinLocation) {
+		t.Errorf("LocationError should match ErrBuiltinLocation")
+	}
+}
@@ -0,0 +1,87 @@
+package persiandate
+
+// OverflowPolicy decides what AddMonths and AddYears do when the day of the
+// month does not exist in the target month, such as 31 Farvardin plus six
+// months or 30 Esfand of a leap year plus one year.
+type OverflowPolicy int
+
+const (
+	// Clamp moves the day back to the last day of the target month, so
+	// 31 Shahrivar plus one month is 30 Mehr. A schedule anchored to day 31
+	// stays on the last day of every month.
+	Clamp OverflowPolicy = iota
+
+	// Overflow carries the missing days into the next month like
+	// time.Time.AddDate, so 31 Shahrivar plus one month is 1 Aban.
+	Overflow
+
+	// Reject returns an error wrapping ErrInvalidDay.
+	Reject
+)
+
+// AddMonths adds months Jalali months to jDate, keeping its clock, and
+// returns the result as the current date of a copy of p. The policy decides
+// what happens when the day does not exist in the target month.
+func (p *PersianDate) AddMonths(jDate JalaliDate, months int, policy OverflowPolicy) *PersianDate {
+	p, err := p.AddMonthsE(jDate, months, policy)
+	if err != nil {
+		panic(err)
+	}
+	return p
+}
+
+// AddMonthsE is like AddMonths but returns an error instead of panicking
+func (p *PersianDate) AddMonthsE(jDate JalaliDate, months int, policy OverflowPolicy) (*PersianDate, error) {
+	d, err := p.addMonths(jDate, months, policy)
+	if err != nil {
+		return p, err
+	}
+	return p.withDate(d), nil
+}
+
+// AddYears adds years Jalali years to jDate like AddMonths. Only 30 Esfand
+// of a leap year can be missing from the target year.
+func (p *PersianDate) AddYears(jDate JalaliDate, years int, policy OverflowPolicy) *PersianDate {
+	return p.AddMonths(jDate, 12*years, policy)
+}
+
+// AddYearsE is like AddYears but returns an error instead of panicking
+func (p *PersianDate) AddYearsE(jDate JalaliDate, years int, policy OverflowPolicy) (*PersianDate, error) {
+	return p.AddMonthsE(jDate, 12*years, policy)
+}
+
+// addMonths moves jDate by months on the Jalali year and month fields and
+// applies policy to a day past the end of the target month
+func (p *PersianDate) addMonths(jDate JalaliDate, months int, policy OverflowPolicy) (JalaliDate, error) {
+	if err := p.validateJalaliDate(jDate); err != nil {
+		return JalaliDate{}, err
+	}
+	total := jDate.Year*12 + jDate.Month - 1 + months
+	d := jDate
+	d.Year, d.Month = floorDiv(total, 12), floorMod(total, 12)+1
+	if first, last := p.YearRange(); d.Year < first || d.Year > last {
+		return JalaliDate{}, yearOutOfRange("Jalali", d.Year)
+	}
+
+	length := p.JalaliMonthLength(d.Year, d.Month)
+	if d.Day <= length {
+		return d, nil
+	}
+	switch policy {
+	case Clamp:
+		d.Day = length
+		return d, nil
+	case Overflow:
+		jdn, err := p.jalaliToJulianDay(d.Year, d.Month, length)
+		if err != nil {
+			return JalaliDate{}, err
+		}
+		next, err := p.julianDayToJalali(jdn + d.Day - length)
+		if err != nil {
+			return JalaliDate{}, err
+		}
+		next.Hour, next.Minute, next.Second = d.Hour, d.Minute, d.Second
+		return next, nil
+	}
+	return JalaliDate{}, invalidDay("Jalali", d.Day)
+}
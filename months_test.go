@@ -0,0 +1,110 @@
+package persiandate_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	persiandate "github.com/NothingMotion/PersianDate"
+)
+
+func TestAddMonthsPolicies(t *testing.T) {
+	pd := persiandate.New("")
+
+	tests := []struct {
+		start    persiandate.JalaliDate
+		months   int
+		policy   persiandate.OverflowPolicy
+		expected string // empty for ErrInvalidDay
+	}{
+		{jalali(1403, 1, 31), 1, persiandate.Clamp, "1403-02-31"},
+		{jalali(1403, 6, 31), 1, persiandate.Clamp, "1403-07-30"},
+		{jalali(1403, 6, 31), 1, persiandate.Overflow, "1403-08-01"},
+		{jalali(1403, 6, 31), 1, persiandate.Reject, ""},
+		{jalali(1403, 6, 30), 1, persiandate.Reject, "1403-07-30"},
+		{jalali(1402, 11, 30), 1, persiandate.Clamp, "1402-12-29"},
+		{jalali(1402, 11, 30), 1, persiandate.Overflow, "1403-01-01"},
+		{jalali(1403, 1, 15), -1, persiandate.Reject, "1402-12-15"},
+		{jalali(1403, 1, 31), -13, persiandate.Clamp, "1401-12-29"},
+		{jalali(1403, 12, 30), 12, persiandate.Clamp, "1404-12-29"},
+		{jalali(1403, 12, 30), 12, persiandate.Overflow, "1405-01-01"},
+		{jalali(1403, 12, 30), 12, persiandate.Reject, ""},
+		{jalali(1403, 12, 30), -48, persiandate.Reject, "1399-12-30"}, // 1399 is a leap year
+	}
+
+	for _, test := range tests {
+		got, err := pd.AddMonthsE(test.start, test.months, test.policy)
+		if test.expected == "" {
+			if !errors.Is(err, persiandate.ErrInvalidDay) {
+				t.Errorf("AddMonthsE(%v, %d, %d) error = %v, expected ErrInvalidDay", test.start, test.months, test.policy, err)
+			}
+			continue
+		}
+		if err != nil || got.Date().String() != test.expected {
+			t.Errorf("AddMonthsE(%v, %d, %d) = %v, %v, expected %s", test.start, test.months, test.policy, got.Date(), err, test.expected)
+		}
+	}
+
+	leapDay := jalali(1403, 12, 30)
+	if got := pd.AddYears(leapDay, 1, persiandate.Clamp).Date(); got.String() != "1404-12-29" {
+		t.Errorf("AddYears(1403-12-30, 1, Clamp) = %v, expected 1404-12-29", got)
+	}
+	if _, err := pd.AddYearsE(jalali(3177, 1, 1), 1, persiandate.Clamp); !errors.Is(err, persiandate.ErrYearOutOfRange) {
+		t.Errorf("AddYearsE(3177-01-01, 1) error = %v, expected ErrYearOutOfRange", err)
+	}
+}
+
+func TestAddMonthsBillingOnDay31(t *testing.T) {
+	pd := persiandate.New("")
+	anchor := jalali(1403, 1, 31)
+	anchor.Hour, anchor.Minute = 9, 30
+
+	// Every due date is computed from the anchor, so a short month does not
+	// pull the following ones back
+	for i := 0; i < 24; i++ {
+		due := pd.AddMonths(anchor, i, persiandate.Clamp).Date()
+		expected := min(31, pd.JalaliMonthLength(due.Year, due.Month))
+		if due.Month != (i%12)+1 || due.Day != expected || due.Hour != 9 || due.Minute != 30 {
+			t.Errorf("due date %d = %v %02d:%02d, expected day %d of month %d at 09:30", i, due, due.Hour, due.Minute, expected, (i%12)+1)
+		}
+	}
+}
+
+func TestAddDateIsJalali(t *testing.T) {
+	pd := persiandate.New("")
+
+	tests := []struct {
+		start    persiandate.JalaliDate
+		y, m, d  int
+		expected string
+	}{
+		{jalali(1403, 1, 31), 0, 1, 0, "1403-02-31"}, // not one Gregorian month
+		{jalali(1402, 6, 31), 0, 6, 0, "1403-01-02"}, // Esfand 1402 has 29 days
+		{jalali(1403, 12, 30), 1, 0, 0, "1405-01-01"},
+		{jalali(1403, 7, 1), 0, -1, 10, "1403-06-11"},
+		{jalali(1403, 1, 1), 0, 0, -1, "1402-12-29"},
+	}
+
+	for _, test := range tests {
+		if got := pd.AddDate(test.start, test.y, test.m, test.d).Date(); got.String() != test.expected {
+			t.Errorf("AddDate(%v, %d, %d, %d) = %v, expected %s", test.start, test.y, test.m, test.d, got, test.expected)
+		}
+	}
+}
+
+func TestTimeAddMonths(t *testing.T) {
+	tehran := time.FixedZone("+0330", 3*3600+1800)
+	start := persiandate.NewTime(1403, 6, 31, 18, 45, 30, 500, tehran)
+
+	clamped, err := start.AddMonths(1, persiandate.Clamp)
+	if err != nil || clamped.String() != "1403-07-30 18:45:30.0000005 +0330 +0330" {
+		t.Errorf("AddMonths(1, Clamp) = %v, %v", clamped, err)
+	}
+	if _, err := start.AddMonths(1, persiandate.Reject); !errors.Is(err, persiandate.ErrInvalidDay) {
+		t.Errorf("AddMonths(1, Reject) error = %v, expected ErrInvalidDay", err)
+	}
+	overflowed, err := start.AddYears(1, persiandate.Overflow)
+	if err != nil || overflowed.Date().String() != "1404-06-31" {
+		t.Errorf("AddYears(1, Overflow) = %v, %v", overflowed, err)
+	}
+}
@@ -0,0 +1,56 @@
+package persiandate
+
+import "time"
+
+// Option configures a PersianDate created by New or Instance
+type Option func(*PersianDate)
+
+// WithLocation sets the location used by Now and NowFull.
+// Without it Asia/Tehran is used, falling back to the built-in offset table
+// when the system zoneinfo is missing.
+func WithLocation(loc *time.Location) Option {
+	return func(p *PersianDate) {
+		p.location = loc
+	}
+}
+
+// WithClock sets the clock used by Now, NowFull, Until and Since.
+// Use FixedClock or a FakeClock to pin "today" in tests.
+func WithClock(c Clock) Option {
+	return func(p *PersianDate) {
+		p.clock = c
+	}
+}
+
+// WithLeapRule sets the rule that decides the Jalali leap years, which every
+// conversion, month length and leap year check uses. The default is BreakTable.
+func WithLeapRule(rule LeapRule) Option {
+	return func(p *PersianDate) {
+		p.rule = rule
+	}
+}
+
+// WithAlgorithm sets the leap rule by the name of its algorithm, the same as
+// WithLeapRule(a.LeapRule()). The default is Borkowski.
+func WithAlgorithm(a Algorithm) Option {
+	return WithLeapRule(a.LeapRule())
+}
+
+// WithLeapDayBirthday sets the birthday of people born on 30 Esfand in the
+// years without it. The default is Esfand29.
+func WithLeapDayBirthday(policy LeapDayBirthday) Option {
+	return func(p *PersianDate) {
+		p.leapDayBirthday = policy
+	}
+}
+
+// WithFiscalYearStart sets the month the fiscal year, and so its first
+// quarter, starts in. The default is 1, Farvardin, the Iranian fiscal year,
+// whose quarters are the seasons. Months outside 1 to 12 are ignored.
+func WithFiscalYearStart(month int) Option {
+	return func(p *PersianDate) {
+		if month >= 1 && month <= 12 {
+			p.fiscalStart = month
+		}
+	}
+}
@@ -0,0 +1,163 @@
+package persiandate
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Period is an amount of Jalali calendar time, such as 2 years, 3 months and
+// 11 days. All of its fields have the same sign.
+type Period struct {
+	Years   int
+	Months  int
+	Days    int
+	Hours   int
+	Minutes int
+	Seconds int
+}
+
+// IsZero reports whether every field of d is zero
+func (d Period) IsZero() bool {
+	return d == Period{}
+}
+
+// String returns d in the ISO 8601 duration format, such as "P2Y3M11D" or
+// "P1DT2H30M". A negative period has a single leading minus sign, as in
+// "-P2Y3M11D". A period whose fields have mixed signs keeps the sign of each
+// field, as in "P1M-2D".
+func (d Period) String() string {
+	if d.IsZero() {
+		return "P0D"
+	}
+	var b strings.Builder
+	sign := 1
+	if d.Years <= 0 && d.Months <= 0 && d.Days <= 0 && d.Hours <= 0 && d.Minutes <= 0 && d.Seconds <= 0 {
+		b.WriteString("-")
+		sign = -1
+	}
+	b.WriteString("P")
+	write := func(value int, unit string) {
+		if value != 0 {
+			b.WriteString(strconv.Itoa(sign * value))
+			b.WriteString(unit)
+		}
+	}
+	write(d.Years, "Y")
+	write(d.Months, "M")
+	write(d.Days, "D")
+	if d.Hours != 0 || d.Minutes != 0 || d.Seconds != 0 {
+		b.WriteString("T")
+		write(d.Hours, "H")
+		write(d.Minutes, "M")
+		write(d.Seconds, "S")
+	}
+	return b.String()
+}
+
+// Between returns the period from a to b, including their clocks, counted
+// in whole Jalali months first and then in days, hours, minutes and seconds.
+// It is negative if b is before a.
+//
+// Months are added with the Clamp policy: one month after 31 Shahrivar is
+// 30 Mehr and one year after 30 Esfand of a leap year is 29 Esfand. So the
+// period from 31 Shahrivar to 30 Mehr is one month, and AddPeriod(a,
+// Between(a, b)) always gives b.
+func (p *PersianDate) Between(a, b JalaliDate) Period {
+	d, err := p.BetweenE(a, b)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+// BetweenE is like Between but returns an error instead of panicking
+func (p *PersianDate) BetweenE(a, b JalaliDate) (Period, error) {
+	start, err := p.secondsOf(a)
+	if err != nil {
+		return Period{}, err
+	}
+	end, err := p.secondsOf(b)
+	if err != nil {
+		return Period{}, err
+	}
+	sign := 1
+	if end < start {
+		sign = -1
+	}
+
+	// Take as many whole months as fit between a and b. Going one month past
+	// the month difference always overshoots, so at most one step back is needed.
+	months := (b.Year*12 + b.Month) - (a.Year*12 + a.Month)
+	var anchor int64
+	for {
+		d, err := p.addMonths(a, months, Clamp)
+		if err != nil {
+			return Period{}, err
+		}
+		if anchor, err = p.secondsOf(d); err != nil {
+			return Period{}, err
+		}
+		if (end-anchor)*int64(sign) >= 0 {
+			break
+		}
+		months -= sign
+	}
+
+	rest := end - anchor
+	return Period{
+		Years:   months / 12,
+		Months:  months % 12,
+		Days:    int(rest / 86400),
+		Hours:   int(rest % 86400 / 3600),
+		Minutes: int(rest % 3600 / 60),
+		Seconds: int(rest % 60),
+	}, nil
+}
+
+// AddPeriod adds period to jDate and returns the result as the current date
+// of a copy of p. Years and months are added first with the Clamp policy,
+// then days and the clock fields, which carry into the date.
+func (p *PersianDate) AddPeriod(jDate JalaliDate, period Period) *PersianDate {
+	p, err := p.AddPeriodE(jDate, period)
+	if err != nil {
+		panic(err)
+	}
+	return p
+}
+
+// AddPeriodE is like AddPeriod but returns an error instead of panicking
+func (p *PersianDate) AddPeriodE(jDate JalaliDate, period Period) (*PersianDate, error) {
+	d, err := p.addPeriod(jDate, period)
+	if err != nil {
+		return p, err
+	}
+	return p.withDate(d), nil
+}
+
+func (p *PersianDate) addPeriod(jDate JalaliDate, period Period) (JalaliDate, error) {
+	d, err := p.addMonths(jDate, 12*period.Years+period.Months, Clamp)
+	if err != nil {
+		return JalaliDate{}, err
+	}
+	seconds := clockSeconds(d) + period.Days*86400 + period.Hours*3600 + period.Minutes*60 + period.Seconds
+	if d, err = p.addDays(d, floorDiv(seconds, 86400)); err != nil {
+		return JalaliDate{}, err
+	}
+	seconds = floorMod(seconds, 86400)
+	d.Hour, d.Minute, d.Second = seconds/3600, seconds%3600/60, seconds%60
+	return d, nil
+}
+
+// secondsOf returns the wall clock seconds of d since the start of the
+// Julian day line
+func (p *PersianDate) secondsOf(d JalaliDate) (int64, error) {
+	jdn, err := p.jalaliToJulianDay(d.Year, d.Month, d.Day)
+	if err != nil {
+		return 0, err
+	}
+	return int64(jdn)*86400 + int64(clockSeconds(d)), nil
+}
+
+func clockSeconds(d JalaliDate) int {
+	return d.Hour*3600 + d.Minute*60 + d.Second
+}
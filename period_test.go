@@ -0,0 +1,89 @@
+package persiandate_test
+
+import (
+	"testing"
+
+	persiandate "github.com/NothingMotion/PersianDate"
+)
+
+func TestBetween(t *testing.T) {
+	pd := persiandate.New("")
+
+	at := func(y, m, d, hour, min int) persiandate.JalaliDate {
+		date := jalali(y, m, d)
+		date.Hour, date.Minute = hour, min
+		return date
+	}
+
+	tests := []struct {
+		a, b     persiandate.JalaliDate
+		expected string
+	}{
+		{jalali(1400, 1, 1), jalali(1402, 4, 12), "P2Y3M11D"},
+		{jalali(1402, 4, 12), jalali(1400, 1, 1), "-P2Y3M11D"},
+		{jalali(1403, 6, 31), jalali(1403, 7, 30), "P1M"}, // 31 Shahrivar clamps to 30 Mehr
+		{jalali(1403, 6, 31), jalali(1403, 7, 29), "P29D"},
+		{jalali(1403, 12, 30), jalali(1404, 12, 29), "P1Y"}, // 30 Esfand clamps to 29 Esfand
+		{jalali(1403, 12, 30), jalali(1405, 1, 1), "P1Y1D"},
+		{jalali(1403, 12, 30), jalali(1408, 12, 30), "P5Y"}, // 1408 is the next leap year
+		{jalali(1402, 1, 1), jalali(1402, 1, 1), "P0D"},
+		{at(1403, 1, 1, 22, 0), at(1403, 1, 3, 6, 30), "P1DT8H30M"},
+		{at(1403, 1, 3, 6, 30), at(1403, 1, 1, 22, 0), "-P1DT8H30M"},
+		{at(1403, 1, 31, 10, 0), at(1403, 2, 31, 9, 0), "P30DT23H"},
+	}
+
+	for _, test := range tests {
+		got := pd.Between(test.a, test.b)
+		if got.String() != test.expected {
+			t.Errorf("Between(%v, %v) = %v, expected %s", test.a, test.b, got, test.expected)
+		}
+		if back := pd.AddPeriod(test.a, got).Date(); back != test.b {
+			t.Errorf("AddPeriod(%v, %v) = %v, expected %v", test.a, got, back, test.b)
+		}
+	}
+
+	period := pd.Between(jalali(1400, 1, 1), jalali(1402, 4, 12))
+	if period != (persiandate.Period{Years: 2, Months: 3, Days: 11}) {
+		t.Errorf("Between(1400-01-01, 1402-04-12) = %+v", period)
+	}
+}
+
+func TestPeriodString(t *testing.T) {
+	tests := []struct {
+		period   persiandate.Period
+		expected string
+	}{
+		{persiandate.Period{}, "P0D"},
+		{persiandate.Period{Years: -1}, "-P1Y"},
+		{persiandate.Period{Days: -3, Seconds: -5}, "-P3DT5S"},
+		{persiandate.Period{Months: 1, Days: -2}, "P1M-2D"},
+	}
+
+	for _, test := range tests {
+		if got := test.period.String(); got != test.expected {
+			t.Errorf("%+v.String() = %s, expected %s", test.period, got, test.expected)
+		}
+	}
+}
+
+func TestBetweenRoundTrip(t *testing.T) {
+	pd := persiandate.New("")
+	first, _ := persiandate.JDNFromJalali(1402, 10, 1)
+	last, _ := persiandate.JDNFromJalali(1403, 2, 31)
+	from, _ := persiandate.JDNFromJalali(1402, 9, 1)
+	to, _ := persiandate.JDNFromJalali(1404, 3, 31)
+
+	for i := first; i <= last; i++ {
+		a, _ := i.ToJalali()
+		for j := from; j <= to; j++ {
+			b, _ := j.ToJalali()
+			period := pd.Between(a, b)
+			if back := pd.AddPeriod(a, period).Date(); back != b {
+				t.Fatalf("AddPeriod(%v, Between(%v, %v) = %v) = %v", a, a, b, period, back)
+			}
+			if period.Months < -11 || period.Months > 11 || period.Days < -30 || period.Days > 30 {
+				t.Fatalf("Between(%v, %v) = %v is not normalized", a, b, period)
+			}
+		}
+	}
+}
@@ -1,8 +1,10 @@
 package persiandate
 
 import (
+	"cmp"
 	"errors"
 	"fmt"
+	"slices"
 	"strconv"
 	"strings"
 	"sync"
@@ -20,8 +22,18 @@ type PersianDate struct {
 	persianShortDays   []string
 	persianSeasons     []string
 
-	currentDate JalaliDate
-	targetDate  JalaliDate
+	location *time.Location
+	clock    Clock
+	rule     LeapRule
+
+	leapDayBirthday LeapDayBirthday // birthday of 30 Esfand in common years
+	fiscalStart     int             // first month of the fiscal year, 0 for Farvardin
+
+	currentDate       JalaliDate
+	currentNanosecond int
+	currentLocation   *time.Location
+	currentOffset     int
+	targetDate        JalaliDate
 }
 
 type DateResponse struct {
@@ -83,6 +95,30 @@ func (j JalaliDate) String() string {
 	return j.Date.String()
 }
 
+// JalaliDateTime is a Jalali date with the full clock, location and zone
+// offset of the time.Time it was converted from, so converting it back is
+// lossless. A nil Location means time.Local.
+type JalaliDateTime struct {
+	JalaliDate
+	Nanosecond int
+	Location   *time.Location
+	// Offset is the zone offset in seconds east of UTC the clock was read
+	// in. It tells apart the two instants of a clock repeated when daylight
+	// saving time ends.
+	Offset int
+}
+
+func (j JalaliDateTime) String() string {
+	s := fmt.Sprintf("%s %02d:%02d:%02d", j.Date.String(), j.Hour, j.Minute, j.Second)
+	if j.Nanosecond != 0 {
+		s += strings.TrimRight(fmt.Sprintf(".%09d", j.Nanosecond), "0")
+	}
+	if j.Location != nil {
+		s += " " + j.Location.String()
+	}
+	return s
+}
+
 type jalCalReturn struct {
 	leap  int
 	gy    int
@@ -92,27 +128,63 @@ type jalCalReturn struct {
 var once sync.Once
 var instance *PersianDate
 
-// Instance creates a new PersianDate object which is a singleton
-func Instance(format string) *PersianDate {
+// Instance creates a new PersianDate object which is a singleton.
+// It is safe for concurrent use because no method modifies its receiver.
+// Options are only applied by the first call.
+func Instance(format string, opts ...Option) *PersianDate {
 	once.Do(func() {
-		instance = &PersianDate{FORMAT: format, persianNumbers: PersianNumbers, latinNumbers: LatinNumbers, persianMonths: PersianMonths, persianShortMonths: PersianShortMonths, persianDays: PersianDays, persianShortDays: PersianShortDays, persianSeasons: PersianSeasons}
+		instance = New(format, opts...)
 	})
 	return instance
 }
 
 // NewPersianDate creates a new PersianDate object which is not a singleton
-func New(format string) *PersianDate {
-	return &PersianDate{FORMAT: format, persianNumbers: PersianNumbers, latinNumbers: LatinNumbers, persianMonths: PersianMonths, persianShortMonths: PersianShortMonths, persianDays: PersianDays, persianShortDays: PersianShortDays, persianSeasons: PersianSeasons}
+func New(format string, opts ...Option) *PersianDate {
+	p := &PersianDate{FORMAT: format, persianNumbers: PersianNumbers, latinNumbers: LatinNumbers, persianMonths: PersianMonths, persianShortMonths: PersianShortMonths, persianDays: PersianDays, persianShortDays: PersianShortDays, persianSeasons: PersianSeasons}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+// withDate returns a copy of p holding date as its current date.
+// Methods never modify their receiver, so a single PersianDate (such as the
+// one returned by Instance) can be shared between goroutines.
+func (p *PersianDate) withDate(date JalaliDate) *PersianDate {
+	return p.withDateTime(JalaliDateTime{JalaliDate: date})
+}
+
+// withDateTime is like withDate but also keeps the nanoseconds, location and
+// offset of dt
+func (p *PersianDate) withDateTime(dt JalaliDateTime) *PersianDate {
+	c := *p
+	c.currentDate = dt.JalaliDate
+	c.currentNanosecond = dt.Nanosecond
+	c.currentLocation = dt.Location
+	c.currentOffset = dt.Offset
+	return &c
 }
 
 func (p *PersianDate) FromTimeFull(t time.Time) PersianDateResponse {
+	response, err := p.FromTimeFullE(t)
+	if err != nil {
+		panic(err)
+	}
+	return response
+}
+
+// FromTimeFullE is like FromTimeFull but returns an error instead of panicking
+func (p *PersianDate) FromTimeFullE(t time.Time) (PersianDateResponse, error) {
 	year, month, day := t.Date()
-	d := p.julianDayToJalali(
+	d, err := p.julianDayToJalali(
 		p.gregorianToJulianDay(year,
 			int(month), // in case if month is 0, it will be 1
 			day,
 		),
 	)
+	if err != nil {
+		return PersianDateResponse{}, err
+	}
 	response := PersianDateResponse{
 		DateResponse: DateResponse{
 			Year:       d.Year,
@@ -125,51 +197,152 @@ func (p *PersianDate) FromTimeFull(t time.Time) PersianDateResponse {
 		},
 	}
 
-	return response
+	return response, nil
 }
+
+// FromTime returns a copy of p whose current date is the Jalali date of t
 func (p *PersianDate) FromTime(t time.Time) *PersianDate {
+	p, err := p.FromTimeE(t)
+	if err != nil {
+		panic(err)
+	}
+	return p
+}
+
+// FromTimeE is like FromTime but returns an error instead of panicking
+func (p *PersianDate) FromTimeE(t time.Time) (*PersianDate, error) {
+	dt, err := p.DateTimeOfE(t)
+	if err != nil {
+		return p, err
+	}
+	return p.withDateTime(dt), nil
+}
+
+// DateTimeOf converts t to a Jalali date keeping its clock, nanoseconds and location
+func (p *PersianDate) DateTimeOf(t time.Time) JalaliDateTime {
+	dt, err := p.DateTimeOfE(t)
+	if err != nil {
+		panic(err)
+	}
+	return dt
+}
+
+// DateTimeOfE is like DateTimeOf but returns an error instead of panicking
+func (p *PersianDate) DateTimeOfE(t time.Time) (JalaliDateTime, error) {
 	year, month, day := t.Date()
 
-	p.currentDate = p.julianDayToJalali(
+	d, err := p.julianDayToJalali(
 		p.gregorianToJulianDay(year,
 			int(month), // in case if month is 0, it will be 1
 			day,
 		),
 	)
-	return p
+	if err != nil {
+		return JalaliDateTime{}, err
+	}
+	d.Hour, d.Minute, d.Second = t.Clock()
+	_, offset := t.Zone()
+	return JalaliDateTime{JalaliDate: d, Nanosecond: t.Nanosecond(), Location: t.Location(), Offset: offset}, nil
 }
 
-func (p *PersianDate) Now() *PersianDate {
-	loc, err := time.LoadLocation("Asia/Tehran")
+// DateTimeToTime converts dt back to a time.Time in dt.Location. When the
+// clock of dt happens twice there, Offset picks the instant; if it matches
+// neither, as in a JalaliDateTime built by hand, time.Date picks it.
+func (p *PersianDate) DateTimeToTime(dt JalaliDateTime) time.Time {
+	t, err := p.DateTimeToTimeE(dt)
 	if err != nil {
-		return p.FromTime(time.Now())
+		panic(err)
+	}
+	return t
+}
 
+// DateTimeToTimeE is like DateTimeToTime but returns an error instead of panicking
+func (p *PersianDate) DateTimeToTimeE(dt JalaliDateTime) (time.Time, error) {
+	g, err := p.ToGregorianE(dt.Year, dt.Month, dt.Day)
+	if err != nil {
+		return time.Time{}, err
+	}
+	loc := dt.Location
+	if loc == nil {
+		loc = time.Local
+	}
+	t := time.Date(g.Year, time.Month(g.Month), g.Day, dt.Hour, dt.Minute, dt.Second, dt.Nanosecond, loc)
+	if _, offset := t.Zone(); offset != dt.Offset {
+		// The same clock in the other offset, if loc has it at that instant
+		other := t.Add(time.Duration(offset-dt.Offset) * time.Second)
+		if _, otherOffset := other.Zone(); otherOffset == dt.Offset {
+			t = other
+		}
 	}
-	return p.FromTime(time.Now().In(loc))
+	return t, nil
+}
 
+// Now returns the current date in the configured location (Asia/Tehran by default)
+func (p *PersianDate) Now() *PersianDate {
+	now, err := p.NowE()
+	if err != nil && !errors.Is(err, ErrBuiltinLocation) {
+		panic(err)
+	}
+	return now
 }
 
+// NowE is like Now but returns an error instead of panicking.
+// If the default location had to fall back to the built-in offset table the
+// date is still returned and the error is a *LocationError.
+func (p *PersianDate) NowE() (*PersianDate, error) {
+	loc, locErr := p.loadLocation()
+	now, err := p.FromTimeE(p.now().In(loc))
+	if err != nil {
+		return now, err
+	}
+	return now, locErr
+}
+
+// NowFull returns the current date and time in the configured location
 func (p *PersianDate) NowFull() PersianDateResponse {
-	loc, err := time.LoadLocation("Asia/Tehran")
+	now, err := p.NowFullE()
+	if err != nil && !errors.Is(err, ErrBuiltinLocation) {
+		panic(err)
+	}
+	return now
+}
+
+// NowFullE is like NowFull but returns an error instead of panicking.
+// It reports the built-in location fallback the same way as NowE.
+func (p *PersianDate) NowFullE() (PersianDateResponse, error) {
+	loc, locErr := p.loadLocation()
+	now, err := p.FromTimeFullE(p.now().In(loc))
 	if err != nil {
-		return p.FromTimeFull(time.Now())
+		return now, err
 	}
-	return p.FromTimeFull(time.Now().In(loc))
+	return now, locErr
 }
 
-// Detect wheter if given persian year is leap year (kabiseh) or not
-func (p *PersianDate) IsLeapYearJalali(year int) bool {
-	if year <= 0 {
-		year = year - 1
+// Location returns the location used by Now and NowFull
+func (p *PersianDate) Location() *time.Location {
+	loc, _ := p.loadLocation()
+	return loc
+}
+
+// now reads the configured clock
+func (p *PersianDate) now() time.Time {
+	if p.clock != nil {
+		return p.clock.Now()
 	}
-	yearsInCycle := year % 33
-	remaineders := []int{1, 5, 9, 13, 17, 22, 26, 30}
-	for _, remaineder := range remaineders {
-		if yearsInCycle == remaineder {
-			return true
-		}
+	return systemClock{}.Now()
+}
+
+func (p *PersianDate) loadLocation() (*time.Location, error) {
+	if p.location != nil {
+		return p.location, nil
 	}
-	return false
+	return Tehran()
+}
+
+// IsLeapYearJalali reports whether the Jalali year has 366 days (kabiseh)
+// under the leap rule of p
+func (p *PersianDate) IsLeapYearJalali(year int) bool {
+	return p.leapRule().IsLeap(year)
 }
 
 func (p *PersianDate) IsLeapYearGregorian(year int) bool {
@@ -211,13 +384,17 @@ func (p *PersianDate) GregorianMonthLength(gy, gm int) int {
 
 		February: 28 days (29 in leap years)
 	*/
+	// a month outside 1-12 has no days
+	if gm < 1 || gm > 12 {
+		return 0
+	}
 	months := []int{31, 28, 31, 30, 31, 30, 31, 31, 30, 31, 30, 31}
 	if p.IsLeapYearGregorian(gy) {
 		months[1] = 29
 	}
 	return months[gm-1]
 }
-func (p *PersianDate) jalCal(jy int, withoutLeap bool) jalCalReturn {
+func (p *PersianDate) jalCal(jy int, withoutLeap bool) (jalCalReturn, error) {
 
 	breaks := []int{-61, 9, 38, 199, 426, 686, 756, 818, 1111, 1181, 1210,
 		1635, 2060, 2097, 2192, 2262, 2324, 2394, 2456, 3178}
@@ -227,7 +404,7 @@ func (p *PersianDate) jalCal(jy int, withoutLeap bool) jalCalReturn {
 	jp := breaks[0]
 
 	if jy < jp || jy >= breaks[bl-1] {
-		panic(errors.New("invalid Jalali year " + fmt.Sprintf("%d", jy)))
+		return jalCalReturn{}, yearOutOfRange("Jalali", jy)
 	}
 
 	// Find the limiting years for the Jalali year jy.
@@ -258,7 +435,7 @@ func (p *PersianDate) jalCal(jy int, withoutLeap bool) jalCalReturn {
 
 	// Return with gy and march when we don't need leap
 	if withoutLeap {
-		return jalCalReturn{leap: 0, gy: gy, march: march}
+		return jalCalReturn{leap: 0, gy: gy, march: march}, nil
 	}
 
 	// Find how many years have passed since the last leap year.
@@ -270,78 +447,111 @@ func (p *PersianDate) jalCal(jy int, withoutLeap bool) jalCalReturn {
 		leap = 4
 	}
 
-	return jalCalReturn{leap: leap, gy: gy, march: march}
+	return jalCalReturn{leap: leap, gy: gy, march: march}, nil
 
 }
-func (p *PersianDate) jalaliToJulianDay(jy, jm, jd int) int {
-	if !p.isValidJalaliDate(JalaliDate{Date: Date{Year: jy, Month: jm, Day: jd}}) {
-		panic(errors.New("invalid Jalali date"))
+func (p *PersianDate) jalaliToJulianDay(jy, jm, jd int) (int, error) {
+	if err := p.validateJalaliDate(JalaliDate{Date: Date{Year: jy, Month: jm, Day: jd}}); err != nil {
+		return 0, err
 	}
-	r := p.jalCal(jy, true)
-	return p.gregorianToJulianDay(r.gy, 3, r.march) + (jm-1)*31 - p.div(jm, 7)*(jm-7) + jd - 1
+	return int(p.leapRule().Nowruz(jy)) + (jm-1)*31 - p.div(jm, 7)*(jm-7) + jd - 1, nil
 }
 
+// gregorianToJulianDay uses floor division on the year terms so that it also
+// holds for the negative years of the proleptic algorithms
 func (p *PersianDate) gregorianToJulianDay(gy, gm, gd int) int {
 	//	days := ((((gm - 8) / 6) + 100100) * 1461) / 4 + ()
 
-	d := p.div((gy+p.div(gm-8, 6)+100100)*1461, 4) +
+	d := floorDiv((gy+p.div(gm-8, 6)+100100)*1461, 4) +
 		p.div(153*p.mod(gm+9, 12)+2, 5) +
 		gd - 34840408
-	d = d - p.div(p.div(gy+100100+p.div(gm-8, 6), 100)*3, 4) + 752
+	d = d - floorDiv(floorDiv(gy+100100+p.div(gm-8, 6), 100)*3, 4) + 752
 	return d
 
 }
-func (p *PersianDate) julianDayToJalali(jdn int) JalaliDate {
-	gy := p.julianDayToGregorian(jdn).Year // Calculate Gregorian year (gy).
-	jy := gy - 621
-	r := p.jalCal(jy, false)
-	jdn1f := p.gregorianToJulianDay(gy, 3, r.march)
-	var jd, jm int
-	k := jdn - jdn1f
-
-	if k >= 0 {
-		if k <= 185 {
-			// The first 6 months.
-			jm = 1 + p.div(k, 31)
-			jd = p.mod(k, 31) + 1
-			return JalaliDate{Date: Date{Year: jy, Month: jm, Day: jd}}
-		} else {
-			// The remaining months.
-			k -= 186
-		}
+func (p *PersianDate) julianDayToJalali(jdn int) (JalaliDate, error) {
+	rule := p.leapRule()
+	first, last := rule.YearRange()
+
+	// Estimate the year from the mean year of 12053 days per 33 years, then
+	// move to the year whose 1 Farvardin is the last one on or before jdn.
+	// The whole cycles are counted apart so that 33 times the days cannot
+	// overflow a 32-bit int.
+	days := jdn - 2460390
+	jy := 1403 + floorDiv(days, 12053)*33 + floorMod(days, 12053)*33/12053
+	jy = max(first, min(jy, last))
+	for jy > first && jdn < int(rule.Nowruz(jy)) {
+		jy--
+	}
+	for jy < last && jdn >= int(rule.Nowruz(jy+1)) {
+		jy++
+	}
+
+	k := jdn - int(rule.Nowruz(jy))
+	if k < 0 {
+		return JalaliDate{}, yearOutOfRange("Jalali", first-1)
+	}
+	yearLength := 365
+	if rule.IsLeap(jy) {
+		yearLength = 366
+	}
+	if k >= yearLength {
+		return JalaliDate{}, yearOutOfRange("Jalali", last+1)
+	}
+
+	var jm, jd int
+	if k < 186 {
+		// The first 6 months.
+		jm = 1 + k/31
+		jd = k%31 + 1
 	} else {
-		// Previous Jalali year.
-		jy -= 1
-		k += 179
-		if r.leap == 1 {
-			k += 1
-		}
+		// The remaining months.
+		k -= 186
+		jm = 7 + k/30
+		jd = k%30 + 1
 	}
-	jm = 7 + p.div(k, 30)
-	jd = p.mod(k, 30) + 1
-	return JalaliDate{Date: Date{Year: jy, Month: jm, Day: jd}}
+	return JalaliDate{Date: Date{Year: jy, Month: jm, Day: jd}}, nil
 }
 
 func (p *PersianDate) julianDayToGregorian(jdn int) GregorianDate {
 
 	j := 4*jdn + 139361631
-	j = j + p.div(p.div(4*jdn+183187720, 146097)*3, 4)*4 - 3908
-	i := p.div(p.mod(j, 1461), 4)*5 + 308
+	j = j + floorDiv(floorDiv(4*jdn+183187720, 146097)*3, 4)*4 - 3908
+	i := p.div(floorMod(j, 1461), 4)*5 + 308
 	gd := p.div(p.mod(i, 153), 5) + 1
 	gm := p.mod(p.div(i, 153), 12) + 1
-	gy := p.div(j, 1461) - 100100 + p.div(8-gm, 6)
+	gy := floorDiv(j, 1461) - 100100 + p.div(8-gm, 6)
 
 	return GregorianDate{Date: Date{Year: gy, Month: gm, Day: gd}}
 
 }
 func (p *PersianDate) isValidJalaliDate(date JalaliDate) bool {
-	if p.isDateEmpty(date.Date) {
-		return false
+	return p.validateJalaliDate(date) == nil
+}
+
+// validateJalaliDate reports which field of a Jalali date is invalid, if any
+func (p *PersianDate) validateJalaliDate(date JalaliDate) error {
+	if first, last := p.YearRange(); date.Year < first || date.Year > last {
+		return yearOutOfRange("Jalali", date.Year)
+	}
+	if date.Month < 1 || date.Month > 12 {
+		return invalidMonth("Jalali", date.Month)
+	}
+	if date.Day < 1 || date.Day > p.JalaliMonthLength(date.Year, date.Month) {
+		return invalidDay("Jalali", date.Day)
 	}
-	validMonth := date.Month >= 1 && date.Month <= 12
-	validDay := date.Day >= 1 && date.Day <= p.JalaliMonthLength(date.Year, date.Month)
-	validYear := date.Year >= 0 && date.Year <= 3778
-	return validMonth && validDay && validYear
+	return nil
+}
+
+// validateGregorianDate reports which field of a Gregorian date is invalid, if any
+func (p *PersianDate) validateGregorianDate(gy, gm, gd int) error {
+	if gm < 1 || gm > 12 {
+		return invalidMonth("Gregorian", gm)
+	}
+	if gd < 1 || gd > p.GregorianMonthLength(gy, gm) {
+		return invalidDay("Gregorian", gd)
+	}
+	return nil
 }
 func (p *PersianDate) isDateEmpty(date Date) bool {
 	return date.Year == 0 && date.Month == 0 && date.Day == 0
@@ -369,51 +579,83 @@ func ToLatinNumbers(text string) string {
 	return text
 }
 
-// ToJalali converts a Gregorian date to Jalali
+// ToJalali converts a Gregorian date to Jalali and returns it as the current date of a copy of p
 func (p *PersianDate) ToJalali(gy, gm, gd int) *PersianDate {
+	p, err := p.ToJalaliE(gy, gm, gd)
+	if err != nil {
+		panic(err)
+	}
+	return p
+}
 
-	p.currentDate = p.julianDayToJalali(p.gregorianToJulianDay(gy, gm, gd))
-	// If gy is a time.Time object, extract the date components
+// ToJalaliE is like ToJalali but returns an error instead of panicking
+func (p *PersianDate) ToJalaliE(gy, gm, gd int) (*PersianDate, error) {
+	if err := p.validateGregorianDate(gy, gm, gd); err != nil {
+		return p, err
+	}
+	d, err := p.julianDayToJalali(p.gregorianToJulianDay(gy, gm, gd))
+	if err != nil {
+		return p, err
+	}
 
-	return p
+	return p.withDate(d), nil
 }
 
 // ToGregorian converts a Jalali date to Gregorian
 func (p *PersianDate) ToGregorian(jy, jm, jd int) GregorianDate {
-	return p.julianDayToGregorian(p.jalaliToJulianDay(jy, jm, jd))
+	g, err := p.ToGregorianE(jy, jm, jd)
+	if err != nil {
+		panic(err)
+	}
+	return g
 }
 
-// JalaliWeek returns Saturday and Friday day of current week (week starts on Saturday)
-func (p *PersianDate) WeekYear(jy, jm, jd int) map[string]JalaliDate {
-	// Get day of week (0 = Saturday, 6 = Friday) based on jalali date
-	p.currentDate = JalaliDate{Date{Year: jy, Month: jm, Day: jd}}
-	dayOfWeek := p.GetWeekDay()
-
-	// Calculate difference to Saturday (start of week in Jalali calendar)
-	// If it's Saturday (0), difference is 0
-	// Otherwise, we need to go back (dayOfWeek + 1) days
-	startDayDifference := 0
-	if dayOfWeek != 0 {
-		startDayDifference = -(dayOfWeek + 1)
+// ToGregorianE is like ToGregorian but returns an error instead of panicking
+func (p *PersianDate) ToGregorianE(jy, jm, jd int) (GregorianDate, error) {
+	jdn, err := p.jalaliToJulianDay(jy, jm, jd)
+	if err != nil {
+		return GregorianDate{}, err
 	}
-	endDayDifference := 6 + startDayDifference
-
-	// Get Julian day number for the current date
-	jdn := p.jalaliToJulianDay(jy, jm, jd)
+	return p.julianDayToGregorian(jdn), nil
+}
 
-	// Calculate Saturday and Friday of the week
-	saturdayDate := p.julianDayToJalali(jdn + startDayDifference)
-	fridayDate := p.julianDayToJalali(jdn + endDayDifference)
+// WeekYear returns the Saturday and Friday of the week of the given date under
+// the "saturday" and "friday" keys, like StartOfWeek and EndOfWeek
+func (p *PersianDate) WeekYear(jy, jm, jd int) map[string]JalaliDate {
+	week, err := p.WeekYearE(jy, jm, jd)
+	if err != nil {
+		panic(err)
+	}
+	return week
+}
 
-	return map[string]JalaliDate{
-		"saturday": saturdayDate,
-		"friday":   fridayDate,
+// WeekYearE is like WeekYear but returns an error instead of panicking
+func (p *PersianDate) WeekYearE(jy, jm, jd int) (map[string]JalaliDate, error) {
+	saturday, friday, err := p.boundsOf(JalaliDate{Date{Year: jy, Month: jm, Day: jd}}, UnitWeek)
+	if err != nil {
+		return nil, err
 	}
+	return map[string]JalaliDate{
+		"saturday": saturday,
+		"friday":   friday,
+	}, nil
 }
 
 // JalaliToTimeObject converts Jalali calendar dates to time.Time object
 func (p *PersianDate) ToTime(jy, jm, jd, h, m, s, ms int) time.Time {
-	GregorianDate := p.ToGregorian(jy, jm, jd)
+	t, err := p.ToTimeE(jy, jm, jd, h, m, s, ms)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+// ToTimeE is like ToTime but returns an error instead of panicking
+func (p *PersianDate) ToTimeE(jy, jm, jd, h, m, s, ms int) (time.Time, error) {
+	GregorianDate, err := p.ToGregorianE(jy, jm, jd)
+	if err != nil {
+		return time.Time{}, err
+	}
 
 	return time.Date(
 		GregorianDate.Year,
@@ -421,13 +663,27 @@ func (p *PersianDate) ToTime(jy, jm, jd, h, m, s, ms int) time.Time {
 		GregorianDate.Day,
 		h, m, s, ms*1000000, // ms to nanoseconds
 		time.Local,
-	)
+	), nil
 }
 
 // FormatJalaliDate formats a Jalali date according to the format string
 func (p *PersianDate) Format(jDate JalaliDate, toPersian ...interface{}) string {
+	formatted, err := p.FormatE(jDate, toPersian...)
+	if err != nil {
+		panic(err)
+	}
+	return formatted
+}
+
+// FormatE is like Format but returns an error instead of panicking
+func (p *PersianDate) FormatE(jDate JalaliDate, toPersian ...interface{}) (string, error) {
 	format := p.FORMAT
 
+	weekDay, err := p.weekDay(jDate)
+	if err != nil {
+		return "", err
+	}
+
 	// Use the time components directly from JalaliDate
 	hour := jDate.Hour
 	minute := jDate.Minute
@@ -490,9 +746,9 @@ func (p *PersianDate) Format(jDate JalaliDate, toPersian ...interface{}) string
 		"rr": PersianMonthDays[min(jDate.Day-1, len(PersianMonthDays)-1)], // Day in Persian words (with bounds check)
 
 		// Weekday formats
-		"l":  p.GetDayName(p.GetWeekDay()),      // Full day name
-		"rh": p.GetDayName(p.GetWeekDay()),      // Full day name (alias)
-		"kh": p.GetShortDayName(p.GetWeekDay()), // Short day name
+		"l":  p.GetDayName(weekDay),      // Full day name
+		"rh": p.GetDayName(weekDay),      // Full day name (alias)
+		"kh": p.GetShortDayName(weekDay), // Short day name
 
 		// Time formats
 		"HH": fmt.Sprintf("%02d", hour),           // 24-hour with leading zero
@@ -509,21 +765,22 @@ func (p *PersianDate) Format(jDate JalaliDate, toPersian ...interface{}) string
 		"A": longAMPM,  // Persian AM/PM full
 
 		// Other formats
-		"L":  leapYearText,                                                // Is leap year
-		"b":  fmt.Sprintf("%d", int(float64(jDate.Month)/float64(3.1)+1)), // Season number
-		"ff": p.GetSeason(jDate.Month),                                    // Season name
+		"L":  leapYearText,                                                   // Is leap year
+		"b":  fmt.Sprintf("%d", (jDate.Month-1)/3+1),                         // Season number
+		"Q":  fmt.Sprintf("%d", p.quarterOf(jDate.Year, jDate.Month).Number), // Fiscal quarter number
+		"ff": p.GetSeason(jDate.Month),                                       // Season name
 	}
 
 	// Full date-time format in Persian style
 	replacements["c"] = fmt.Sprintf("%d/%d/%d ،%d:%d:%d %s",
 		jDate.Year, jDate.Month, jDate.Day,
 		hour, minute, second,
-		p.GetDayName(p.GetWeekDay()))
+		p.GetDayName(weekDay))
 
 	// Apply all replacements (using a custom sort to avoid partial replacements)
 	orderedPatterns := []string{"YYYY", "YYY", "YY", "Y", "y", "MM", "M", "mm", "km", "mb",
 		"DD", "D", "dd", "d", "rr", "l", "rh", "kh", "HH", "H", "hh", "h", "ii", "i", "ss", "s",
-		"a", "A", "L", "b", "ff", "c"}
+		"a", "A", "L", "b", "Q", "ff", "c"}
 
 	for _, pattern := range orderedPatterns {
 		if replacement, exists := replacements[pattern]; exists {
@@ -534,7 +791,7 @@ func (p *PersianDate) Format(jDate JalaliDate, toPersian ...interface{}) string
 	if convertNumbers {
 		format = ToPersianNumbers(format)
 	}
-	return format
+	return format, nil
 }
 
 // Helper function to convert 24-hour format to 12-hour format
@@ -566,105 +823,152 @@ func max(a, b int) int {
 
 // AddDaysToJalali adds days to a Jalali date and returns the new date
 func (p *PersianDate) Add(jDate JalaliDate, days int) *PersianDate {
-	timeObject := p.ToTime(jDate.Year, jDate.Month, jDate.Day, 0, 0, 0, 0)
-	timeObject = timeObject.AddDate(0, 0, days)
-	return p.FromTime(timeObject)
+	p, err := p.AddE(jDate, days)
+	if err != nil {
+		panic(err)
+	}
+	return p
+}
+
+// AddE is like Add but returns an error instead of panicking
+func (p *PersianDate) AddE(jDate JalaliDate, days int) (*PersianDate, error) {
+	d, err := p.addDays(jDate, days)
+	if err != nil {
+		return p, err
+	}
+	return p.withDate(d), nil
+}
 
+// addDays moves jDate by days on the Julian day line, keeping its clock.
+// It never goes through time.Time, so the zone of the process and its
+// daylight saving changes cannot shift the result.
+func (p *PersianDate) addDays(jDate JalaliDate, days int) (JalaliDate, error) {
+	jdn, err := p.jalaliToJulianDay(jDate.Year, jDate.Month, jDate.Day)
+	if err != nil {
+		return JalaliDate{}, err
+	}
+	d, err := p.julianDayToJalali(jdn + days)
+	if err != nil {
+		return JalaliDate{}, err
+	}
+	d.Hour, d.Minute, d.Second = jDate.Hour, jDate.Minute, jDate.Second
+	return d, nil
 }
+
+// AddDate adds y years, m months and d days to jDate on the Jalali calendar
+// and returns the result as the current date of a copy of p. Like
+// time.Time.AddDate a day past the end of the target month overflows into
+// the next month; use AddMonths to choose another OverflowPolicy.
 func (p *PersianDate) AddDate(jDate JalaliDate, y, m, d int) *PersianDate {
-	timeObject := p.ToTime(jDate.Year, jDate.Month, jDate.Day, 0, 0, 0, 0)
-	timeObject = timeObject.AddDate(y, m, d)
-	return p.FromTime(timeObject)
+	p, err := p.AddDateE(jDate, y, m, d)
+	if err != nil {
+		panic(err)
+	}
+	return p
+}
+
+// AddDateE is like AddDate but returns an error instead of panicking
+func (p *PersianDate) AddDateE(jDate JalaliDate, y, m, d int) (*PersianDate, error) {
+	date, err := p.addMonths(jDate, 12*y+m, Overflow)
+	if err != nil {
+		return p, err
+	}
+	return p.AddE(date, d)
 }
 
 // SubtractDaysFromJalali subtracts days from a Jalali date and returns the new date
 func (p *PersianDate) Sub(jDate JalaliDate, days int) *PersianDate {
-	p.Add(jDate, -days)
-	return p
+	return p.Add(jDate, -days)
+}
+
+// SubE is like Sub but returns an error instead of panicking
+func (p *PersianDate) SubE(jDate JalaliDate, days int) (*PersianDate, error) {
+	return p.AddE(jDate, -days)
 }
 
-// Returns oldest date between dates
+// Returns oldest date between dates. Inputs that cannot be converted are
+// skipped; use MinE to find out which.
 func (p *PersianDate) Min(dates ...interface{}) JalaliDate {
+	d, _ := p.MinE(dates...)
+	return d
+}
+
+// MinE is like Min but also reports the skipped inputs the same way as SortE
+func (p *PersianDate) MinE(dates ...interface{}) (JalaliDate, error) {
 	// Handle a slice passed as single argument
 	if len(dates) == 1 {
 		switch d := dates[0].(type) {
 		case []JalaliDate:
 			return p.processMinMax(d, true)
 		case []interface{}:
-			// Convert to sorted dates and return min
-			sortedDates := p.Sort(d...)
-			if len(sortedDates) > 0 {
-				return sortedDates[0]
-			}
-			return JalaliDate{}
+			dates = d
 		}
 	}
 
 	// Process regular variadic arguments
-	sortedDates := p.Sort(dates...)
-	if len(sortedDates) < 1 {
-		return JalaliDate{}
+	jalaliDates, keys, err := p.collectDates(dates)
+	if len(keys) == 0 {
+		return JalaliDate{}, err
 	}
-	return sortedDates[0]
+	return jalaliDates[pickJulianDay(keys, true)], err
 }
 
-// Return newest date between dates
+// Return newest date between dates. Inputs that cannot be converted are
+// skipped; use MaxE to find out which.
 func (p *PersianDate) Max(dates ...interface{}) JalaliDate {
+	d, _ := p.MaxE(dates...)
+	return d
+}
+
+// MaxE is like Max but also reports the skipped inputs the same way as SortE
+func (p *PersianDate) MaxE(dates ...interface{}) (JalaliDate, error) {
 	// Handle a slice passed as single argument
 	if len(dates) == 1 {
 		switch d := dates[0].(type) {
 		case []JalaliDate:
 			return p.processMinMax(d, false)
 		case []interface{}:
-			// Convert to sorted dates and return max
-			sortedDates := p.Sort(d...)
-			if len(sortedDates) > 0 {
-				return sortedDates[len(sortedDates)-1]
-			}
-			return JalaliDate{}
+			dates = d
 		}
 	}
 
 	// Process regular variadic arguments
-	sortedDates := p.Sort(dates...)
-	if len(sortedDates) < 1 {
-		return JalaliDate{}
-	}
-	return sortedDates[len(sortedDates)-1]
-}
-
-// Helper function to process min/max from a slice of JalaliDate
-func (p *PersianDate) processMinMax(dates []JalaliDate, isMin bool) JalaliDate {
-	if len(dates) == 0 {
-		return JalaliDate{}
-	}
-
-	if len(dates) == 1 {
-		return dates[0]
-	}
-
-	index := 0
-	if !isMin {
-		index = len(dates) - 1
-	}
-
-	// Sort by date
-	sortedDates := make([]JalaliDate, len(dates))
-	copy(sortedDates, dates)
-
-	// Sort the dates by converting to Julian days
-	for i := 0; i < len(sortedDates); i++ {
-		for j := i + 1; j < len(sortedDates); j++ {
-			jdni := p.jalaliToJulianDay(sortedDates[i].Year, sortedDates[i].Month, sortedDates[i].Day)
-			jdnj := p.jalaliToJulianDay(sortedDates[j].Year, sortedDates[j].Month, sortedDates[j].Day)
-
-			if jdni > jdnj {
-				sortedDates[i], sortedDates[j] = sortedDates[j], sortedDates[i]
+	jalaliDates, keys, err := p.collectDates(dates)
+	if len(keys) == 0 {
+		return JalaliDate{}, err
+	}
+	return jalaliDates[pickJulianDay(keys, false)], err
+}
+
+// Helper function to process min/max from a slice of JalaliDate.
+// It runs in linear time; among equal dates Min keeps the first and Max the
+// last, matching the order Sort would produce.
+func (p *PersianDate) processMinMax(dates []JalaliDate, isMin bool) (JalaliDate, error) {
+	keys, err := p.julianDays(dates, nil)
+	if len(keys) == 0 {
+		return JalaliDate{}, err
+	}
+	return dates[pickJulianDay(keys, isMin)], err
+}
+
+// julianDays computes the Julian day of every date once. Invalid dates are
+// added to a *RejectedError; inputIndex maps them back to the caller's input.
+func (p *PersianDate) julianDays(dates []JalaliDate, inputIndex []int) ([]julianDayIndex, error) {
+	keys := make([]julianDayIndex, 0, len(dates))
+	var rejected RejectedError
+	for i, d := range dates {
+		jdn, err := p.jalaliToJulianDay(d.Year, d.Month, d.Day)
+		if err != nil {
+			if inputIndex != nil {
+				rejected.add(inputIndex[i], d, err)
+			} else {
+				rejected.add(i, d, err)
 			}
+			continue
 		}
+		keys = append(keys, julianDayIndex{jdn: jdn, index: i})
 	}
-
-	return sortedDates[index]
+	return keys, rejected.err()
 }
 
 // ParseJalaliDateString parses a string in format YYYY-MM-DD to a Jalali date
@@ -689,105 +993,226 @@ func (p *PersianDate) Parse(dateStr string) (JalaliDate, error) {
 		return JalaliDate{}, errors.New("invalid day format")
 	}
 
-	if !p.isValidJalaliDate(JalaliDate{Date: Date{Year: year, Month: month, Day: day}}) {
-		return JalaliDate{}, errors.New("invalid date values")
+	date := JalaliDate{Date: Date{Year: year, Month: month, Day: day}}
+	if err := p.validateJalaliDate(date); err != nil {
+		return JalaliDate{}, err
 	}
 
-	return JalaliDate{Date: Date{Year: year, Month: month, Day: day}}, nil
+	return date, nil
 }
 
 // DaysBetweenJalaliDates calculates the number of days between two Jalali dates
 func (p *PersianDate) Difference(start, end JalaliDate) int {
-	startJDN := p.jalaliToJulianDay(start.Year, start.Month, start.Day)
-	endJDN := p.jalaliToJulianDay(end.Year, end.Month, end.Day)
-	return endJDN - startJDN
+	days, err := p.DifferenceE(start, end)
+	if err != nil {
+		panic(err)
+	}
+	return days
+}
+
+// DifferenceE is like Difference but returns an error instead of panicking
+func (p *PersianDate) DifferenceE(start, end JalaliDate) (int, error) {
+	startJDN, err := p.jalaliToJulianDay(start.Year, start.Month, start.Day)
+	if err != nil {
+		return 0, err
+	}
+	endJDN, err := p.jalaliToJulianDay(end.Year, end.Month, end.Day)
+	if err != nil {
+		return 0, err
+	}
+	return endJDN - startJDN, nil
 }
 
 // Until calculates days until the end date
-// If no date is provided, it uses the current date as the start date
+// If no date is provided, it uses the current date as the start date,
+// or today according to the configured clock when there is no current date
 func (p *PersianDate) Until(end JalaliDate, startOpt ...JalaliDate) int {
+	days, err := p.UntilE(end, startOpt...)
+	if err != nil {
+		panic(err)
+	}
+	return days
+}
+
+// UntilE is like Until but returns an error instead of panicking
+func (p *PersianDate) UntilE(end JalaliDate, startOpt ...JalaliDate) (int, error) {
 	var start JalaliDate
 	if len(startOpt) > 0 {
 		start = startOpt[0]
 	} else {
-		start = p.currentDate
+		var err error
+		if start, err = p.currentOrToday(); err != nil {
+			return 0, err
+		}
 	}
-	return p.Difference(start, end)
+	return p.DifferenceE(start, end)
 }
 
 // Since calculates days since the start date
-// If no date is provided, it uses the current date as the end date
+// If no date is provided, it uses the current date as the end date,
+// or today according to the configured clock when there is no current date
 func (p *PersianDate) Since(start JalaliDate, endOpt ...JalaliDate) int {
+	days, err := p.SinceE(start, endOpt...)
+	if err != nil {
+		panic(err)
+	}
+	return days
+}
+
+// SinceE is like Since but returns an error instead of panicking
+func (p *PersianDate) SinceE(start JalaliDate, endOpt ...JalaliDate) (int, error) {
 	var end JalaliDate
 	if len(endOpt) > 0 {
 		end = endOpt[0]
 	} else {
-		end = p.currentDate
+		var err error
+		if end, err = p.currentOrToday(); err != nil {
+			return 0, err
+		}
 	}
-	return p.Difference(start, end)
+	return p.DifferenceE(start, end)
 }
 
+// currentOrToday returns the current date, or today if p has none
+func (p *PersianDate) currentOrToday() (JalaliDate, error) {
+	if !p.isDateEmpty(p.currentDate.Date) {
+		return p.currentDate, nil
+	}
+	now, err := p.NowE()
+	if err != nil && !errors.Is(err, ErrBuiltinLocation) {
+		return JalaliDate{}, err
+	}
+	return now.Date(), nil
+}
+
+// Equal reports whether a and b are the same day, see JalaliDate.Equal
 func (p *PersianDate) Equal(a, b JalaliDate) bool {
-	return a.Year == b.Year && a.Month == b.Month && a.Day == b.Day
+	return a.Equal(b)
 }
 
+// Sort orders JalaliDate, *JalaliDate, JalaliDateTime, Date (read as Jalali),
+// GregorianDate, PersianDateResponse, GregorianDateResponse, Time, time.Time and
+// "YYYY-MM-DD" Jalali strings from oldest to newest. Equal dates keep their
+// input order. Inputs that cannot be converted are left out; use SortE to
+// find out which.
 func (p *PersianDate) Sort(dates ...interface{}) []JalaliDate {
-	if len(dates) < 1 {
-		fmt.Println("dates to sort are less than 1")
-		return []JalaliDate{}
+	sorted, _ := p.SortE(dates...)
+	return sorted
+}
+
+// SortE is like Sort but also reports the inputs it left out. The accepted
+// dates are returned sorted even when some inputs are rejected; the error is
+// then a *RejectedError listing every rejected input.
+func (p *PersianDate) SortE(dates ...interface{}) ([]JalaliDate, error) {
+	jalaliDates, keys, err := p.collectDates(dates)
+	sortJulianDays(keys)
+
+	sorted := make([]JalaliDate, len(keys))
+	for i, k := range keys {
+		sorted[i] = jalaliDates[k.index]
 	}
-	var jalaliDates []JalaliDate
+	return sorted, err
+}
+
+// collectDates converts the supported input types to Jalali dates and
+// computes their Julian days, rejecting the inputs that cannot be converted
+func (p *PersianDate) collectDates(dates []interface{}) ([]JalaliDate, []julianDayIndex, error) {
+	jalaliDates := make([]JalaliDate, 0, len(dates))
+	inputIndex := make([]int, 0, len(dates))
+	var rejected RejectedError
 
 	// Process each date in the input
-	for _, date := range dates {
-		switch d := date.(type) {
-		case JalaliDate:
-			jalaliDates = append(jalaliDates, d)
-		case Date:
-			jalaliDates = append(jalaliDates, JalaliDate{Date: d})
-		case string:
-			parsed, err := p.Parse(d)
-			if err == nil {
-				jalaliDates = append(jalaliDates, parsed)
-			}
-		case time.Time:
-			response := p.FromTimeFull(d)
-			jalaliDate := JalaliDate{
-				Date: Date{
-					Year:   response.Year,
-					Month:  response.Month,
-					Day:    response.Day,
-					Hour:   response.Hour,
-					Minute: response.Minute,
-					Second: response.Second,
-				},
-			}
-			jalaliDates = append(jalaliDates, jalaliDate)
+	for i, date := range dates {
+		d, err := p.toJalaliDate(date)
+		if err != nil {
+			rejected.add(i, date, err)
+			continue
 		}
+		jalaliDates = append(jalaliDates, d)
+		inputIndex = append(inputIndex, i)
 	}
 
-	// Sort the dates by converting to Julian days
-	for i := 0; i < len(jalaliDates); i++ {
-		for j := i + 1; j < len(jalaliDates); j++ {
-			jdni := p.jalaliToJulianDay(jalaliDates[i].Year, jalaliDates[i].Month, jalaliDates[i].Day)
-			jdnj := p.jalaliToJulianDay(jalaliDates[j].Year, jalaliDates[j].Month, jalaliDates[j].Day)
-
-			if jdni > jdnj {
-				jalaliDates[i], jalaliDates[j] = jalaliDates[j], jalaliDates[i]
-			}
+	keys, err := p.julianDays(jalaliDates, inputIndex)
+	if err != nil {
+		rejected.Inputs = append(rejected.Inputs, err.(*RejectedError).Inputs...)
+		slices.SortFunc(rejected.Inputs, func(a, b RejectedInput) int {
+			return cmp.Compare(a.Index, b.Index)
+		})
+	}
+	return jalaliDates, keys, rejected.err()
+}
+
+// toJalaliDate converts one of the types accepted by Sort to a Jalali date
+func (p *PersianDate) toJalaliDate(date interface{}) (JalaliDate, error) {
+	switch d := date.(type) {
+	case JalaliDate:
+		return d, nil
+	case *JalaliDate:
+		if d == nil {
+			return JalaliDate{}, ErrUnsupportedType
+		}
+		return *d, nil
+	case JalaliDateTime:
+		return d.JalaliDate, nil
+	case Date:
+		return JalaliDate{Date: d}, nil
+	case PersianDateResponse:
+		return JalaliDate{Date: Date{Year: d.Year, Month: d.Month, Day: d.Day, Hour: d.Hour, Minute: d.Minute, Second: d.Second}}, nil
+	case GregorianDate:
+		return p.gregorianToJalaliDate(d.Date)
+	case GregorianDateResponse:
+		return p.gregorianToJalaliDate(Date{Year: d.Year, Month: d.Month, Day: d.Day, Hour: d.Hour, Minute: d.Minute, Second: d.Second})
+	case string:
+		return p.Parse(d)
+	case Time:
+		return d.DateE()
+	case time.Time:
+		response, err := p.FromTimeFullE(d)
+		if err != nil {
+			return JalaliDate{}, err
 		}
+		return JalaliDate{
+			Date: Date{
+				Year:   response.Year,
+				Month:  response.Month,
+				Day:    response.Day,
+				Hour:   response.Hour,
+				Minute: response.Minute,
+				Second: response.Second,
+			},
+		}, nil
+	}
+	return JalaliDate{}, ErrUnsupportedType
+}
+
+// gregorianToJalaliDate converts a Gregorian date keeping its clock fields
+func (p *PersianDate) gregorianToJalaliDate(g Date) (JalaliDate, error) {
+	if err := p.validateGregorianDate(g.Year, g.Month, g.Day); err != nil {
+		return JalaliDate{}, err
+	}
+	d, err := p.julianDayToJalali(p.gregorianToJulianDay(g.Year, g.Month, g.Day))
+	if err != nil {
+		return JalaliDate{}, err
 	}
-
-	return jalaliDates
+	d.Hour, d.Minute, d.Second = g.Hour, g.Minute, g.Second
+	return d, nil
 }
 
+// Filter returns the sorted dates for which comparator returns true. Inputs
+// that cannot be converted are left out like in Sort.
 func (p *PersianDate) Filter(comparator func(JalaliDate) bool, dates ...interface{}) []JalaliDate {
+	filteredDates, _ := p.FilterE(comparator, dates...)
+	return filteredDates
+}
+
+// FilterE is like Filter but also reports the skipped inputs the same way as SortE
+func (p *PersianDate) FilterE(comparator func(JalaliDate) bool, dates ...interface{}) ([]JalaliDate, error) {
 	if len(dates) < 1 {
-		return []JalaliDate{}
+		return []JalaliDate{}, nil
 	}
 
 	// Sort the dates
-	sortedDates := p.Sort(dates...)
+	sortedDates, err := p.SortE(dates...)
 
 	// Filter the dates based on the comparator function
 	var filteredDates []JalaliDate
@@ -797,13 +1222,30 @@ func (p *PersianDate) Filter(comparator func(JalaliDate) bool, dates ...interfac
 		}
 	}
 
-	return filteredDates
+	return filteredDates, err
 }
 
 func (p *PersianDate) GetWeekDay() int {
-	jDate := p.currentDate
-	t := p.ToTime(jDate.Year, jDate.Month, jDate.Day, 0, 0, 0, 0)
-	return int((t.Weekday() + 1) % 7) // conversion to jalali days (saturday from 6 to 0 , and friday to 6)
+	weekDay, err := p.GetWeekDayE()
+	if err != nil {
+		panic(err)
+	}
+	return weekDay
+}
+
+// GetWeekDayE is like GetWeekDay but returns an error instead of panicking
+func (p *PersianDate) GetWeekDayE() (int, error) {
+	return p.weekDay(p.currentDate)
+}
+
+// weekDay returns the day of week of jDate (0 = Saturday, 6 = Friday),
+// computed from its Julian day number independently of time.Local
+func (p *PersianDate) weekDay(jDate JalaliDate) (int, error) {
+	jdn, err := p.jalaliToJulianDay(jDate.Year, jDate.Month, jDate.Day)
+	if err != nil {
+		return 0, err
+	}
+	return JDN(jdn).Weekday(), nil
 }
 func (p *PersianDate) GetYearDay() int {
 	jDate := p.currentDate
@@ -864,6 +1306,13 @@ func (p *PersianDate) Clock() (int, int, int) {
 
 	return p.GetHour(), p.GetMinute(), p.GetSecond()
 }
+
+// DateTime returns the current date together with its nanoseconds, location
+// and offset
+func (p *PersianDate) DateTime() JalaliDateTime {
+	return JalaliDateTime{JalaliDate: p.currentDate, Nanosecond: p.currentNanosecond, Location: p.currentLocation, Offset: p.currentOffset}
+}
+
 func (p *PersianDate) Date() JalaliDate {
 
 	return p.currentDate
@@ -153,6 +153,13 @@ func TestMonthLength(t *testing.T) {
 	if pd.GregorianMonthLength(2024, 2) != 29 {
 		t.Errorf("February in leap year 2024 should have 29 days")
 	}
+
+	// Months outside 1-12 have no days
+	for _, month := range []int{0, 13, -1} {
+		if got := pd.GregorianMonthLength(2024, month); got != 0 {
+			t.Errorf("Month %d in year 2024 has %d days, expected 0", month, got)
+		}
+	}
 }
 
 func TestNumberConversion(t *testing.T) {
@@ -297,7 +304,8 @@ func TestWeekDay(t *testing.T) {
 }
 
 func TestSince(t *testing.T) {
-	pd := persiandate.New("")
+	clock := persiandate.FixedClock(time.Date(2025, 3, 30, 12, 0, 0, 0, time.UTC))
+	pd := persiandate.New("", persiandate.WithClock(clock))
 	date := pd.ToJalali(2025, 3, 29).Date()
 	diff := pd.Since(date, pd.Now().Date())
 
@@ -308,7 +316,8 @@ func TestSince(t *testing.T) {
 }
 
 func TestUntil(t *testing.T) {
-	pd := persiandate.New("")
+	clock := persiandate.FixedClock(time.Date(2025, 3, 30, 12, 0, 0, 0, time.UTC))
+	pd := persiandate.New("", persiandate.WithClock(clock))
 
 	date := pd.Now().Date()
 	remained := pd.Until(pd.ToJalali(2025, 4, 29).Date(), date)
@@ -334,13 +343,16 @@ func TestNewDate(t *testing.T) {
 	pd := persiandate.New("YYYY/MM/DD")
 	pd2 := pd.ToJalali(2025, 3, 29)
 
-	start := pd.Date()
+	start := pd2.Date()
 	days := pd.Until(pd.ToJalali(2025, 3, 30).Date(), start)
 
 	t.Logf("remaining days: %d", days)
+	if days != 1 {
+		t.Errorf("TestNewDate() remaining days = %d, expected 1", days)
+	}
 
-	if pd != pd2 {
-		t.Errorf("TestNewDate() Not the same date")
+	if pd == pd2 || pd.Date() != (persiandate.JalaliDate{}) {
+		t.Errorf("TestNewDate() ToJalali modified its receiver")
 	}
 	t.Logf("pd: %v", pd.Date())
 	t.Logf("pd2: %v", pd2.Date())
@@ -348,7 +360,9 @@ func TestNewDate(t *testing.T) {
 
 func TestDateInstance(t *testing.T) {
 	pd := persiandate.Instance("YY/MM/dd")
-	formatted := pd.ToJalali(2025, 3, 29).Add(pd.Date(), 1).Format(pd.Date())
+	date := pd.ToJalali(2025, 3, 29)
+	date = date.Add(date.Date(), 1)
+	formatted := date.Format(date.Date())
 	t.Logf(formatted)
 }
 func TestDateArthematic(t *testing.T) {
@@ -356,11 +370,10 @@ func TestDateArthematic(t *testing.T) {
 	pd := persiandate.New("YYYY/MM/DD")
 	target := pd.ToJalali(2025, 4, 29).Date()
 
-	diff := pd.
-		ToJalali(2025, 3, 29).
-		Add(pd.Date(), 1).
-		Sub(pd.Date(), 1).
-		Difference(pd.Date(), target)
+	date := pd.ToJalali(2025, 3, 29)
+	date = date.Add(date.Date(), 1)
+	date = date.Sub(date.Date(), 1)
+	diff := date.Difference(date.Date(), target)
 	if diff != 31 {
 		t.Errorf("expected 31 got %v", diff)
 	}
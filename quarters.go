@@ -0,0 +1,118 @@
+package persiandate
+
+import "fmt"
+
+// Quarter is a three month quarter of a fiscal year. A fiscal year is named
+// after the Jalali year it starts in, so with WithFiscalYearStart(7) the
+// quarter {1403, 3} runs from Farvardin to Khordad 1404. With the default
+// Iranian fiscal year the quarters are the seasons.
+type Quarter struct {
+	Year   int // fiscal year
+	Number int // 1 to 4
+}
+
+// String returns the quarter as "1403-Q2"
+func (q Quarter) String() string {
+	return fmt.Sprintf("%d-Q%d", q.Year, q.Number)
+}
+
+// Next returns the quarter after q
+func (q Quarter) Next() Quarter {
+	if q.Number >= 4 {
+		return Quarter{Year: q.Year + 1, Number: 1}
+	}
+	return Quarter{Year: q.Year, Number: q.Number + 1}
+}
+
+// Quarter returns the fiscal quarter containing d
+func (p *PersianDate) Quarter(d JalaliDate) Quarter {
+	q, err := p.QuarterE(d)
+	if err != nil {
+		panic(err)
+	}
+	return q
+}
+
+// QuarterE is like Quarter but returns an error instead of panicking
+func (p *PersianDate) QuarterE(d JalaliDate) (Quarter, error) {
+	if err := p.validateJalaliDate(d); err != nil {
+		return Quarter{}, err
+	}
+	return p.quarterOf(d.Year, d.Month), nil
+}
+
+// QuarterBounds returns the first and last day of the quarter q of the
+// fiscal year
+func (p *PersianDate) QuarterBounds(year, q int) (JalaliDate, JalaliDate) {
+	start, end, err := p.QuarterBoundsE(year, q)
+	if err != nil {
+		panic(err)
+	}
+	return start, end
+}
+
+// QuarterBoundsE is like QuarterBounds but returns an error instead of panicking
+func (p *PersianDate) QuarterBoundsE(year, q int) (JalaliDate, JalaliDate, error) {
+	if q < 1 || q > 4 {
+		return JalaliDate{}, JalaliDate{}, invalidQuarter(q)
+	}
+	// Months counted from Farvardin of the fiscal year
+	first := p.firstMonth() - 1 + 3*(q-1)
+	last := first + 2
+	start := JalaliDate{Date: Date{Year: year + first/12, Month: first%12 + 1, Day: 1}}
+	end := JalaliDate{Date: Date{Year: year + last/12, Month: last%12 + 1}}
+	if err := p.validateJalaliDate(start); err != nil {
+		return JalaliDate{}, JalaliDate{}, err
+	}
+	end.Day = 1
+	if err := p.validateJalaliDate(end); err != nil {
+		return JalaliDate{}, JalaliDate{}, err
+	}
+	end.Day = p.JalaliMonthLength(end.Year, end.Month)
+	return start, end, nil
+}
+
+// Quarters returns, in order, every quarter with a day between from and to
+// inclusive. It is empty if to is before from.
+func (p *PersianDate) Quarters(from, to JalaliDate) []Quarter {
+	quarters, err := p.QuartersE(from, to)
+	if err != nil {
+		panic(err)
+	}
+	return quarters
+}
+
+// QuartersE is like Quarters but returns an error instead of panicking
+func (p *PersianDate) QuartersE(from, to JalaliDate) ([]Quarter, error) {
+	first, err := p.QuarterE(from)
+	if err != nil {
+		return nil, err
+	}
+	last, err := p.QuarterE(to)
+	if err != nil {
+		return nil, err
+	}
+	var quarters []Quarter
+	for q := first; q.Year < last.Year || (q.Year == last.Year && q.Number <= last.Number); q = q.Next() {
+		quarters = append(quarters, q)
+	}
+	return quarters, nil
+}
+
+// firstMonth returns the first month of the fiscal year
+func (p *PersianDate) firstMonth() int {
+	if p.fiscalStart == 0 {
+		return 1
+	}
+	return p.fiscalStart
+}
+
+// quarterOf returns the fiscal quarter of the month of the Jalali year
+func (p *PersianDate) quarterOf(year, month int) Quarter {
+	offset := month - p.firstMonth()
+	if offset < 0 {
+		offset += 12
+		year--
+	}
+	return Quarter{Year: year, Number: offset/3 + 1}
+}
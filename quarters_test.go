@@ -0,0 +1,107 @@
+package persiandate_test
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	persiandate "github.com/NothingMotion/PersianDate"
+)
+
+func TestQuarter(t *testing.T) {
+	pd := persiandate.New("")
+	fiscal := persiandate.New("", persiandate.WithFiscalYearStart(4))
+
+	tests := []struct {
+		date           persiandate.JalaliDate
+		season, fiscal string
+	}{
+		{jalali(1403, 1, 1), "1403-Q1", "1402-Q4"},
+		{jalali(1403, 3, 31), "1403-Q1", "1402-Q4"},
+		{jalali(1403, 4, 1), "1403-Q2", "1403-Q1"},
+		{jalali(1403, 9, 30), "1403-Q3", "1403-Q2"},
+		{jalali(1403, 10, 1), "1403-Q4", "1403-Q3"},
+		{jalali(1403, 12, 30), "1403-Q4", "1403-Q3"},
+	}
+
+	for _, test := range tests {
+		if q := pd.Quarter(test.date); q.String() != test.season {
+			t.Errorf("Quarter(%v) = %v, expected %s", test.date, q, test.season)
+		}
+		if q := fiscal.Quarter(test.date); q.String() != test.fiscal {
+			t.Errorf("Quarter(%v) starting in Tir = %v, expected %s", test.date, q, test.fiscal)
+		}
+	}
+
+	if _, err := pd.QuarterE(jalali(1402, 12, 30)); !errors.Is(err, persiandate.ErrInvalidDay) {
+		t.Errorf("QuarterE(1402-12-30) error = %v, expected ErrInvalidDay", err)
+	}
+}
+
+func TestQuarterBounds(t *testing.T) {
+	tests := []struct {
+		first, year, q int
+		start, end     string
+	}{
+		{1, 1403, 1, "1403-01-01", "1403-03-31"},
+		{1, 1403, 3, "1403-07-01", "1403-09-30"},
+		{1, 1403, 4, "1403-10-01", "1403-12-30"},
+		{1, 1402, 4, "1402-10-01", "1402-12-29"},
+		{4, 1402, 4, "1403-01-01", "1403-03-31"},
+		{11, 1402, 1, "1402-11-01", "1403-01-31"},
+		{11, 1402, 2, "1403-02-01", "1403-04-31"},
+	}
+
+	for _, test := range tests {
+		pd := persiandate.New("", persiandate.WithFiscalYearStart(test.first))
+		start, end := pd.QuarterBounds(test.year, test.q)
+		if start.String() != test.start || end.String() != test.end {
+			t.Errorf("QuarterBounds(%d, %d) starting in month %d = %v to %v, expected %s to %s",
+				test.year, test.q, test.first, start, end, test.start, test.end)
+		}
+		if q := pd.Quarter(start); q != (persiandate.Quarter{Year: test.year, Number: test.q}) {
+			t.Errorf("Quarter(%v) starting in month %d = %v, expected %d-Q%d", start, test.first, q, test.year, test.q)
+		}
+	}
+
+	pd := persiandate.New("")
+	for _, q := range []int{0, 5} {
+		if _, _, err := pd.QuarterBoundsE(1403, q); !errors.Is(err, persiandate.ErrInvalidQuarter) {
+			t.Errorf("QuarterBoundsE(1403, %d) error = %v, expected ErrInvalidQuarter", q, err)
+		}
+	}
+	if _, _, err := pd.QuarterBoundsE(3178, 1); !errors.Is(err, persiandate.ErrYearOutOfRange) {
+		t.Errorf("QuarterBoundsE(3178, 1) error = %v, expected ErrYearOutOfRange", err)
+	}
+}
+
+func TestQuarters(t *testing.T) {
+	pd := persiandate.New("", persiandate.WithFiscalYearStart(7))
+
+	var got []string
+	for _, q := range pd.Quarters(jalali(1402, 5, 10), jalali(1403, 7, 1)) {
+		got = append(got, q.String())
+	}
+	expected := []string{"1401-Q4", "1402-Q1", "1402-Q2", "1402-Q3", "1402-Q4", "1403-Q1"}
+	if !slices.Equal(got, expected) {
+		t.Errorf("Quarters(1402-05-10, 1403-07-01) = %v, expected %v", got, expected)
+	}
+
+	if got := pd.Quarters(jalali(1403, 7, 1), jalali(1403, 1, 1)); len(got) != 0 {
+		t.Errorf("Quarters with to before from = %v, expected none", got)
+	}
+}
+
+func TestQuarterAndSeasonTokens(t *testing.T) {
+	for month := 1; month <= 12; month++ {
+		date := jalali(1403, month, 1)
+		season := (month-1)/3 + 1
+		if got := persiandate.New("b Q").Format(date); got != string(rune('0'+season))+" "+string(rune('0'+season)) {
+			t.Errorf("Format(1403-%02d-01, \"b Q\") = %s, expected season and quarter %d", month, got, season)
+		}
+		quarter := persiandate.New("Q", persiandate.WithFiscalYearStart(10)).Format(date)
+		if want := (month+2)%12/3 + 1; quarter != string(rune('0'+want)) {
+			t.Errorf("Format(1403-%02d-01, \"Q\") starting in Dey = %s, expected %d", month, quarter, want)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package persiandate_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	persiandate "github.com/NothingMotion/PersianDate"
+)
+
+// Run with -race: a shared instance must not leak dates between goroutines.
+func TestSharedInstanceConcurrency(t *testing.T) {
+	pd := persiandate.Instance("YYYY/MM/DD l")
+
+	base := time.Date(2023, 3, 21, 0, 0, 0, 0, time.UTC) // 1402-01-01
+	var wg sync.WaitGroup
+	for i := 0; i < 32; i++ {
+		wg.Add(1)
+		go func(offset int) {
+			defer wg.Done()
+			for j := 0; j < 200; j++ {
+				days := offset*200 + j
+				expected := persiandate.TimeOf(base.AddDate(0, 0, days)).Date()
+
+				got := pd.FromTime(base.AddDate(0, 0, days)).Date()
+				if got.Year != expected.Year || got.Month != expected.Month || got.Day != expected.Day {
+					t.Errorf("FromTime(+%d days) = %v, expected %v", days, got, expected)
+					return
+				}
+
+				added := pd.Add(persiandate.JalaliDate{Date: persiandate.Date{Year: 1402, Month: 1, Day: 1}}, days).Date()
+				if !pd.Equal(added, expected) {
+					t.Errorf("Add(1402-01-01, %d) = %v, expected %v", days, added, expected)
+					return
+				}
+
+				_ = pd.Format(got)
+				_ = pd.WeekYear(got.Year, got.Month, got.Day)
+				_ = pd.Now().Date()
+				_ = pd.NowFull()
+			}
+		}(i)
+	}
+	wg.Wait()
+}
+
+func TestMethodsDoNotModifyReceiver(t *testing.T) {
+	pd := persiandate.New("YYYY/MM/DD")
+	date := pd.ToJalali(2023, 3, 21)
+
+	_ = date.FromTime(time.Date(2024, 3, 20, 0, 0, 0, 0, time.UTC))
+	_ = date.Add(date.Date(), 10)
+	_ = date.AddDate(date.Date(), 1, 0, 0)
+	_ = date.Sub(date.Date(), 10)
+	_ = date.WeekYear(1403, 1, 1)
+	_ = date.Format(persiandate.JalaliDate{Date: persiandate.Date{Year: 1403, Month: 1, Day: 1}})
+
+	if got := date.Date(); got.Year != 1402 || got.Month != 1 || got.Day != 1 {
+		t.Errorf("current date changed to %v, expected 1402-01-01", got)
+	}
+}
@@ -0,0 +1,501 @@
+package persiandate
+
+import (
+	"errors"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Frequency is the FREQ of a recurrence rule
+type Frequency int
+
+const (
+	Yearly Frequency = iota + 1
+	Monthly
+	Weekly
+	Daily
+)
+
+func (f Frequency) String() string {
+	switch f {
+	case Yearly:
+		return "YEARLY"
+	case Monthly:
+		return "MONTHLY"
+	case Weekly:
+		return "WEEKLY"
+	case Daily:
+		return "DAILY"
+	}
+	return "Frequency(" + strconv.Itoa(int(f)) + ")"
+}
+
+// ruleWeekdays are the RFC 5545 weekday codes, indexed from Saturday
+var ruleWeekdays = [7]string{"SA", "SU", "MO", "TU", "WE", "TH", "FR"}
+
+// RuleDay is a BYDAY entry: a weekday with an optional ordinal, such as the
+// second Saturday (2SA) or the last Friday (-1FR) of the month or year
+type RuleDay struct {
+	Weekday int // 0 for Saturday to 6 for Friday, like GetDayName
+	N       int // 0 for every such weekday
+}
+
+// Rule is an RFC 5545 recurrence rule evaluated on the Jalali calendar.
+// BYMONTH counts the Jalali months from Farvardin, BYMONTHDAY and BYYEARDAY
+// count the days of the Jalali month and year, and weekly rules and BYWEEKNO
+// use Saturday to Friday weeks, week 1 being the first with at least four
+// days of the year. Negative values count from the end.
+//
+// Occurrences are whole days and carry the clock of Start. As in most
+// implementations Start itself is an occurrence only if it matches the rule.
+type Rule struct {
+	Start      JalaliDate // DTSTART
+	Freq       Frequency
+	Interval   int        // 0 is treated as 1
+	Count      int        // 0 for no limit
+	Until      JalaliDate // last day an occurrence can be on, zero for no limit
+	ByMonth    []int
+	ByMonthDay []int
+	ByYearDay  []int
+	ByWeekNo   []int
+	ByDay      []RuleDay
+	ExDates    []JalaliDate // days removed after Count is applied
+}
+
+// ParseRule parses a recurrence rule with its DTSTART and EXDATE lines:
+//
+//	DTSTART:14030715T090000
+//	RRULE:FREQ=YEARLY;BYMONTH=7;BYMONTHDAY=15
+//	EXDATE:14050715,14060715
+//
+// The dates are Jalali, in the basic format yyyymmdd with an optional
+// Thhmmss; a trailing Z and parameters such as TZID are ignored. A line
+// without a name is read as the RRULE. WKST can only be SA, and BYSETPOS and
+// the parts for the time of day are not supported.
+func ParseRule(s string) (Rule, error) {
+	var r Rule
+	for _, line := range strings.Split(s, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		name, value, found := strings.Cut(line, ":")
+		if !found {
+			name, value = "RRULE", line
+		}
+		name, _, _ = strings.Cut(strings.ToUpper(name), ";")
+
+		switch name {
+		case "DTSTART":
+			d, ok := parseRuleDate(value)
+			if !ok {
+				return Rule{}, &RuleError{Part: line, Reason: "expected yyyymmdd or yyyymmddThhmmss"}
+			}
+			r.Start = d
+		case "EXDATE":
+			for _, v := range strings.Split(value, ",") {
+				d, ok := parseRuleDate(v)
+				if !ok {
+					return Rule{}, &RuleError{Part: line, Reason: "expected yyyymmdd or yyyymmddThhmmss"}
+				}
+				r.ExDates = append(r.ExDates, d)
+			}
+		case "RRULE":
+			if r.Freq != 0 {
+				return Rule{}, &RuleError{Part: line, Reason: "only one RRULE is supported"}
+			}
+			for _, part := range strings.Split(value, ";") {
+				if err := r.parsePart(part); err != nil {
+					return Rule{}, err
+				}
+			}
+			if r.Freq == 0 {
+				return Rule{}, &RuleError{Part: line, Reason: "missing FREQ"}
+			}
+		default:
+			return Rule{}, &RuleError{Part: line, Reason: "not supported"}
+		}
+	}
+	if r.Freq == 0 {
+		return Rule{}, &RuleError{Part: "RRULE", Reason: "missing"}
+	}
+	return r, r.validate()
+}
+
+func (r *Rule) parsePart(part string) error {
+	key, value, found := strings.Cut(part, "=")
+	if !found {
+		return &RuleError{Part: part, Reason: "expected NAME=VALUE"}
+	}
+	invalid := &RuleError{Part: part, Reason: "malformed value"}
+
+	var err error
+	switch strings.ToUpper(key) {
+	case "FREQ":
+		switch strings.ToUpper(value) {
+		case "YEARLY":
+			r.Freq = Yearly
+		case "MONTHLY":
+			r.Freq = Monthly
+		case "WEEKLY":
+			r.Freq = Weekly
+		case "DAILY":
+			r.Freq = Daily
+		default:
+			return &RuleError{Part: part, Reason: "only YEARLY, MONTHLY, WEEKLY and DAILY are supported"}
+		}
+	case "INTERVAL":
+		r.Interval, err = strconv.Atoi(value)
+	case "COUNT":
+		r.Count, err = strconv.Atoi(value)
+	case "UNTIL":
+		var ok bool
+		if r.Until, ok = parseRuleDate(value); !ok {
+			return invalid
+		}
+	case "BYMONTH":
+		r.ByMonth, err = parseRuleInts(value)
+	case "BYMONTHDAY":
+		r.ByMonthDay, err = parseRuleInts(value)
+	case "BYYEARDAY":
+		r.ByYearDay, err = parseRuleInts(value)
+	case "BYWEEKNO":
+		r.ByWeekNo, err = parseRuleInts(value)
+	case "BYDAY":
+		for _, v := range strings.Split(strings.ToUpper(value), ",") {
+			if len(v) < 2 {
+				return invalid
+			}
+			weekday := slices.Index(ruleWeekdays[:], v[len(v)-2:])
+			n := 0
+			if ordinal := v[:len(v)-2]; ordinal != "" {
+				n, err = strconv.Atoi(ordinal)
+			}
+			if weekday < 0 || err != nil {
+				return invalid
+			}
+			r.ByDay = append(r.ByDay, RuleDay{Weekday: weekday, N: n})
+		}
+	case "WKST":
+		if strings.ToUpper(value) != "SA" {
+			return &RuleError{Part: part, Reason: "weeks start on Saturday"}
+		}
+	default:
+		return &RuleError{Part: part, Reason: "not supported"}
+	}
+	if err != nil {
+		return invalid
+	}
+	return nil
+}
+
+func parseRuleInts(s string) ([]int, error) {
+	var values []int
+	for _, v := range strings.Split(s, ",") {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, n)
+	}
+	return values, nil
+}
+
+// parseRuleDate parses yyyymmdd or yyyymmddThhmmss, with an optional Z
+func parseRuleDate(s string) (JalaliDate, bool) {
+	s = strings.TrimSuffix(strings.TrimSpace(s), "Z")
+	date, clock, hasClock := strings.Cut(s, "T")
+	if len(date) != 8 || (hasClock && len(clock) != 6) {
+		return JalaliDate{}, false
+	}
+	fields := []string{date[:4], date[4:6], date[6:]}
+	if hasClock {
+		fields = append(fields, clock[:2], clock[2:4], clock[4:])
+	}
+	var values [6]int
+	for i, field := range fields {
+		n, err := strconv.Atoi(field)
+		if err != nil || n < 0 {
+			return JalaliDate{}, false
+		}
+		values[i] = n
+	}
+	return JalaliDate{Date: Date{
+		Year: values[0], Month: values[1], Day: values[2],
+		Hour: values[3], Minute: values[4], Second: values[5],
+	}}, true
+}
+
+// validate checks the ranges of the parts of r and the combinations RFC 5545
+// does not allow
+func (r Rule) validate() error {
+	if r.Freq < Yearly || r.Freq > Daily {
+		return &RuleError{Part: "FREQ=" + r.Freq.String(), Reason: "only YEARLY, MONTHLY, WEEKLY and DAILY are supported"}
+	}
+	if r.Interval < 0 {
+		return &RuleError{Part: "INTERVAL=" + strconv.Itoa(r.Interval), Reason: "must be positive"}
+	}
+	if r.Count < 0 {
+		return &RuleError{Part: "COUNT=" + strconv.Itoa(r.Count), Reason: "must be positive"}
+	}
+	if r.Count > 0 && !r.Until.IsZero() {
+		return &RuleError{Part: "UNTIL", Reason: "not allowed with COUNT"}
+	}
+
+	checks := []struct {
+		name   string
+		values []int
+		limit  int
+		signed bool
+	}{
+		{"BYMONTH", r.ByMonth, 12, false},
+		{"BYMONTHDAY", r.ByMonthDay, 31, true},
+		{"BYYEARDAY", r.ByYearDay, 366, true},
+		{"BYWEEKNO", r.ByWeekNo, 53, true},
+	}
+	for _, check := range checks {
+		for _, v := range check.values {
+			if v == 0 || v > check.limit || v < -check.limit || (v < 0 && !check.signed) {
+				return &RuleError{Part: check.name + "=" + strconv.Itoa(v), Reason: "out of range"}
+			}
+		}
+	}
+	for _, day := range r.ByDay {
+		if day.Weekday < 0 || day.Weekday > 6 || day.N > 53 || day.N < -53 {
+			return &RuleError{Part: "BYDAY", Reason: "out of range"}
+		}
+		if day.N != 0 && (r.Freq == Weekly || r.Freq == Daily || len(r.ByWeekNo) > 0) {
+			return &RuleError{Part: "BYDAY", Reason: "ordinals need FREQ=MONTHLY or YEARLY without BYWEEKNO"}
+		}
+	}
+
+	switch {
+	case len(r.ByWeekNo) > 0 && r.Freq != Yearly:
+		return &RuleError{Part: "BYWEEKNO", Reason: "needs FREQ=YEARLY"}
+	case len(r.ByYearDay) > 0 && r.Freq != Yearly:
+		return &RuleError{Part: "BYYEARDAY", Reason: "needs FREQ=YEARLY"}
+	case len(r.ByMonthDay) > 0 && r.Freq == Weekly:
+		return &RuleError{Part: "BYMONTHDAY", Reason: "not allowed with FREQ=WEEKLY"}
+	}
+	return nil
+}
+
+// Occurrences returns the occurrences of r on the days from from to to
+// inclusive. COUNT counts the occurrences from Start, including those before
+// from.
+func (p *PersianDate) Occurrences(r Rule, from, to JalaliDate) []JalaliDate {
+	occurrences, err := p.OccurrencesE(r, from, to)
+	if err != nil {
+		panic(err)
+	}
+	return occurrences
+}
+
+// OccurrencesE is like Occurrences but returns an error instead of panicking
+func (p *PersianDate) OccurrencesE(r Rule, from, to JalaliDate) ([]JalaliDate, error) {
+	if err := r.validate(); err != nil {
+		return nil, err
+	}
+	start, err := p.jalaliToJulianDay(r.Start.Year, r.Start.Month, r.Start.Day)
+	if err != nil {
+		return nil, err
+	}
+	first, err := p.jalaliToJulianDay(from.Year, from.Month, from.Day)
+	if err != nil {
+		return nil, err
+	}
+	last, err := p.jalaliToJulianDay(to.Year, to.Month, to.Day)
+	if err != nil {
+		return nil, err
+	}
+	if !r.Until.IsZero() {
+		until, err := p.jalaliToJulianDay(r.Until.Year, r.Until.Month, r.Until.Day)
+		if err != nil {
+			return nil, err
+		}
+		last = min(last, until)
+	}
+	excluded := make(map[int]bool, len(r.ExDates))
+	for _, d := range r.ExDates {
+		jdn, err := p.jalaliToJulianDay(d.Year, d.Month, d.Day)
+		if err != nil {
+			return nil, err
+		}
+		excluded[jdn] = true
+	}
+	r = r.withDefaults(JDN(start).Weekday())
+
+	var occurrences []JalaliDate
+	count := 0
+	for period := 0; ; period++ {
+		begin, days, err := p.rulePeriod(r, start, period*max(r.Interval, 1))
+		if errors.Is(err, ErrYearOutOfRange) || begin > last {
+			// Periods only move forward, so the calendar ended after to
+			return occurrences, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		for jdn := max(begin, start); jdn < begin+days; jdn++ {
+			if jdn > last || (r.Count > 0 && count == r.Count) {
+				return occurrences, nil
+			}
+			d, err := p.julianDayToJalali(jdn)
+			if err != nil {
+				return nil, err
+			}
+			if !p.ruleMatches(r, d, jdn) {
+				continue
+			}
+			count++
+			if jdn >= first && !excluded[jdn] {
+				d.Hour, d.Minute, d.Second = r.Start.Hour, r.Start.Minute, r.Start.Second
+				occurrences = append(occurrences, d)
+			}
+		}
+	}
+}
+
+// withDefaults takes the parts a rule leaves out from Start, so that a
+// yearly rule repeats on the month and day of Start, a monthly rule on its
+// day and a weekly rule on its weekday
+func (r Rule) withDefaults(weekday int) Rule {
+	if len(r.ByWeekNo) > 0 || len(r.ByYearDay) > 0 || len(r.ByMonthDay) > 0 || len(r.ByDay) > 0 {
+		return r
+	}
+	switch r.Freq {
+	case Yearly:
+		if len(r.ByMonth) == 0 {
+			r.ByMonth = []int{r.Start.Month}
+		}
+		r.ByMonthDay = []int{r.Start.Day}
+	case Monthly:
+		r.ByMonthDay = []int{r.Start.Day}
+	case Weekly:
+		r.ByDay = []RuleDay{{Weekday: weekday}}
+	}
+	return r
+}
+
+// rulePeriod returns the first Julian day and the number of days of the year,
+// month, week or day that is offset periods of the frequency of r after the
+// one containing start
+func (p *PersianDate) rulePeriod(r Rule, start, offset int) (int, int, error) {
+	switch r.Freq {
+	case Yearly:
+		year := r.Start.Year + offset
+		begin, err := p.jalaliToJulianDay(year, 1, 1)
+		if err != nil {
+			return 0, 0, err
+		}
+		days := 365
+		if p.IsLeapYearJalali(year) {
+			days = 366
+		}
+		return begin, days, nil
+	case Monthly:
+		total := r.Start.Year*12 + r.Start.Month - 1 + offset
+		year, month := floorDiv(total, 12), floorMod(total, 12)+1
+		begin, err := p.jalaliToJulianDay(year, month, 1)
+		if err != nil {
+			return 0, 0, err
+		}
+		return begin, p.JalaliMonthLength(year, month), nil
+	case Weekly:
+		return start - JDN(start).Weekday() + 7*offset, 7, nil
+	}
+	return start + offset, 1, nil
+}
+
+// ruleMatches reports whether the day d, whose Julian day is jdn, passes
+// every BYxxx part of r
+func (p *PersianDate) ruleMatches(r Rule, d JalaliDate, jdn int) bool {
+	if len(r.ByMonth) > 0 && !slices.Contains(r.ByMonth, d.Month) {
+		return false
+	}
+	nowruz, err := p.jalaliToJulianDay(d.Year, 1, 1)
+	if err != nil {
+		return false
+	}
+	yearDay, yearLength := jdn-nowruz+1, 365
+	if p.IsLeapYearJalali(d.Year) {
+		yearLength = 366
+	}
+	monthLength := p.JalaliMonthLength(d.Year, d.Month)
+
+	if len(r.ByMonthDay) > 0 && !matchesRuleNumber(r.ByMonthDay, d.Day, monthLength) {
+		return false
+	}
+	if len(r.ByYearDay) > 0 && !matchesRuleNumber(r.ByYearDay, yearDay, yearLength) {
+		return false
+	}
+	if len(r.ByWeekNo) > 0 {
+		week, weeks, err := p.weekOfYear(d.Year, jdn)
+		if err != nil || !matchesRuleNumber(r.ByWeekNo, week, weeks) {
+			return false
+		}
+	}
+	if len(r.ByDay) > 0 {
+		// Ordinals count within the month for monthly rules and yearly rules
+		// with BYMONTH, and within the year otherwise
+		index, length := yearDay-1, yearLength
+		if r.Freq == Monthly || len(r.ByMonth) > 0 {
+			index, length = d.Day-1, monthLength
+		}
+		weekday := JDN(jdn).Weekday()
+		return slices.ContainsFunc(r.ByDay, func(day RuleDay) bool {
+			return day.Weekday == weekday && (day.N == 0 || day.N == index/7+1 || day.N == -((length-1-index)/7+1))
+		})
+	}
+	return true
+}
+
+// matchesRuleNumber reports whether n, counted from 1 to length, is one of
+// values, which count back from -1 when negative
+func matchesRuleNumber(values []int, n, length int) bool {
+	for _, v := range values {
+		if v == n || v == n-length-1 {
+			return true
+		}
+	}
+	return false
+}
+
+// weekOfYear returns the Saturday to Friday week of jdn in the Jalali year
+// jy and the number of weeks in that week's year. Week 1 is the first week
+// with at least four days of its year, so the first days of a year can be in
+// the last week of the year before and the last days in week 1 of the next.
+func (p *PersianDate) weekOfYear(jy, jdn int) (int, int, error) {
+	firstWeek := func(jy int) (int, error) {
+		nowruz, err := p.jalaliToJulianDay(jy, 1, 1)
+		if err != nil {
+			return 0, err
+		}
+		saturday := nowruz - JDN(nowruz).Weekday()
+		if JDN(nowruz).Weekday() > 3 {
+			saturday += 7
+		}
+		return saturday, nil
+	}
+
+	switch this, err := firstWeek(jy); {
+	case err != nil:
+		return 0, 0, err
+	case jdn < this:
+		jy--
+	default:
+		if next, err := firstWeek(jy + 1); err == nil && jdn >= next {
+			jy++
+		}
+	}
+	begin, err := firstWeek(jy)
+	if err != nil {
+		return 0, 0, err
+	}
+	end, err := firstWeek(jy + 1)
+	if err != nil {
+		return 0, 0, err
+	}
+	return (jdn-begin)/7 + 1, (end - begin) / 7, nil
+}
@@ -0,0 +1,142 @@
+package persiandate_test
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	persiandate "github.com/NothingMotion/PersianDate"
+)
+
+func TestRuleOccurrences(t *testing.T) {
+	pd := persiandate.New("")
+
+	tests := []struct {
+		name     string
+		rule     string
+		from, to persiandate.JalaliDate
+		expected []string
+	}{
+		{"last day of every month", "DTSTART:14021101\nRRULE:FREQ=MONTHLY;BYMONTHDAY=-1",
+			jalali(1402, 11, 1), jalali(1403, 2, 31),
+			[]string{"1402-11-30", "1402-12-29", "1403-01-31", "1403-02-31"}},
+		{"every 15 Mehr", "DTSTART:14000715T090000\nRRULE:FREQ=YEARLY\nEXDATE:14020715",
+			jalali(1400, 1, 1), jalali(1404, 12, 29),
+			[]string{"1400-07-15", "1401-07-15", "1403-07-15", "1404-07-15"}},
+		{"second Saturday of each season", "DTSTART:14040101\nRRULE:FREQ=MONTHLY;INTERVAL=3;BYDAY=2SA",
+			jalali(1404, 1, 1), jalali(1404, 12, 29),
+			[]string{"1404-01-09", "1404-04-14", "1404-07-12", "1404-10-13"}},
+		{"second Saturday of the first months", "DTSTART:14040101\nRRULE:FREQ=YEARLY;BYMONTH=1,4,7,10;BYDAY=+2SA",
+			jalali(1404, 1, 1), jalali(1404, 12, 29),
+			[]string{"1404-01-09", "1404-04-14", "1404-07-12", "1404-10-13"}},
+		{"last Friday of the year", "DTSTART:14020101\nRRULE:FREQ=YEARLY;BYDAY=-1FR",
+			jalali(1402, 1, 1), jalali(1403, 12, 30),
+			[]string{"1402-12-25", "1403-12-24"}},
+		{"1st of every month", "DTSTART:14030101\nRRULE:FREQ=MONTHLY",
+			jalali(1403, 1, 1), jalali(1403, 12, 30),
+			[]string{"1403-01-01", "1403-02-01", "1403-03-01", "1403-04-01", "1403-05-01", "1403-06-01",
+				"1403-07-01", "1403-08-01", "1403-09-01", "1403-10-01", "1403-11-01", "1403-12-01"}},
+		{"months with a 31st", "DTSTART:14030131\nRRULE:FREQ=MONTHLY",
+			jalali(1403, 1, 1), jalali(1403, 12, 30),
+			[]string{"1403-01-31", "1403-02-31", "1403-03-31", "1403-04-31", "1403-05-31", "1403-06-31"}},
+		{"first and last day of the year", "DTSTART:14020101\nRRULE:FREQ=YEARLY;BYYEARDAY=1,-1",
+			jalali(1402, 1, 1), jalali(1403, 12, 30),
+			[]string{"1402-01-01", "1402-12-29", "1403-01-01", "1403-12-30"}},
+		{"first and last week", "DTSTART:14030101\nRRULE:FREQ=YEARLY;BYWEEKNO=1,-1;BYDAY=SA;WKST=SA",
+			jalali(1403, 1, 1), jalali(1403, 12, 30),
+			[]string{"1403-01-04", "1403-12-25"}},
+		{"every other weekend", "DTSTART:14040101\nRRULE:FREQ=WEEKLY;INTERVAL=2;BYDAY=SA,FR",
+			jalali(1404, 1, 1), jalali(1404, 1, 31),
+			[]string{"1404-01-01", "1404-01-09", "1404-01-15", "1404-01-23", "1404-01-29"}},
+		{"weekly until", "DTSTART:14040102\nRRULE:FREQ=WEEKLY;UNTIL=14040120",
+			jalali(1404, 1, 1), jalali(1404, 12, 29),
+			[]string{"1404-01-02", "1404-01-09", "1404-01-16"}},
+		{"count before from", "DTSTART:14031228\nRRULE:FREQ=DAILY;COUNT=5",
+			jalali(1404, 1, 1), jalali(1404, 12, 29),
+			[]string{"1404-01-01", "1404-01-02"}},
+		{"count with exdate", "DTSTART:14031228\nRRULE:FREQ=DAILY;COUNT=5\nEXDATE:14040101",
+			jalali(1403, 1, 1), jalali(1404, 12, 29),
+			[]string{"1403-12-28", "1403-12-29", "1403-12-30", "1404-01-02"}},
+	}
+
+	for _, test := range tests {
+		rule, err := persiandate.ParseRule(test.rule)
+		if err != nil {
+			t.Errorf("%s: ParseRule returned error: %v", test.name, err)
+			continue
+		}
+		var got []string
+		for _, d := range pd.Occurrences(rule, test.from, test.to) {
+			got = append(got, d.String())
+		}
+		if !slices.Equal(got, test.expected) {
+			t.Errorf("%s: occurrences = %v, expected %v", test.name, got, test.expected)
+		}
+	}
+}
+
+func TestRuleKeepsStartClock(t *testing.T) {
+	rule, err := persiandate.ParseRule("DTSTART;TZID=Asia/Tehran:14030715T093000\nFREQ=YEARLY;COUNT=2")
+	if err != nil {
+		t.Fatalf("ParseRule returned error: %v", err)
+	}
+	got := persiandate.New("").Occurrences(rule, jalali(1403, 1, 1), jalali(1410, 1, 1))
+	if len(got) != 2 || got[1].String() != "1404-07-15" || got[1].Hour != 9 || got[1].Minute != 30 {
+		t.Errorf("occurrences = %v, expected 1403-07-15 and 1404-07-15 at 09:30", got)
+	}
+}
+
+func TestRuleBuiltByHand(t *testing.T) {
+	rule := persiandate.Rule{
+		Start: jalali(1403, 1, 1),
+		Freq:  persiandate.Monthly,
+		ByDay: []persiandate.RuleDay{{Weekday: 6, N: -1}},
+		Count: 3,
+	}
+	var got []string
+	for _, d := range persiandate.New("").Occurrences(rule, jalali(1403, 1, 1), jalali(1403, 12, 30)) {
+		got = append(got, d.String())
+	}
+	if expected := []string{"1403-01-31", "1403-02-28", "1403-03-25"}; !slices.Equal(got, expected) {
+		t.Errorf("last Friday of the month = %v, expected %v", got, expected)
+	}
+}
+
+func TestParseRuleErrors(t *testing.T) {
+	rules := []string{
+		"FREQ=HOURLY",
+		"BYMONTH=1",
+		"FREQ=MONTHLY;BYMONTHDAY=32",
+		"FREQ=MONTHLY;BYMONTHDAY=0",
+		"FREQ=YEARLY;BYMONTH=-1",
+		"FREQ=WEEKLY;BYDAY=2SA",
+		"FREQ=WEEKLY;BYMONTHDAY=1",
+		"FREQ=MONTHLY;BYWEEKNO=1",
+		"FREQ=MONTHLY;BYYEARDAY=1",
+		"FREQ=YEARLY;BYWEEKNO=1;BYDAY=1SA",
+		"FREQ=DAILY;COUNT=3;UNTIL=14040101",
+		"FREQ=DAILY;BYSETPOS=1",
+		"FREQ=DAILY;WKST=MO",
+		"FREQ=DAILY;BYDAY=XX",
+		"FREQ=DAILY;INTERVAL=x",
+		"DTSTART:1404-01-01\nFREQ=DAILY",
+		"FREQ=DAILY\nRRULE:FREQ=WEEKLY",
+		"RDATE:14040101\nFREQ=DAILY",
+	}
+	for _, rule := range rules {
+		_, err := persiandate.ParseRule(rule)
+		var ruleErr *persiandate.RuleError
+		if !errors.Is(err, persiandate.ErrInvalidRule) || !errors.As(err, &ruleErr) {
+			t.Errorf("ParseRule(%q) error = %v, expected a *RuleError", rule, err)
+		}
+	}
+
+	pd := persiandate.New("")
+	if _, err := pd.OccurrencesE(persiandate.Rule{Start: jalali(1403, 1, 1)}, jalali(1403, 1, 1), jalali(1403, 2, 1)); !errors.Is(err, persiandate.ErrInvalidRule) {
+		t.Errorf("OccurrencesE without Freq error = %v, expected ErrInvalidRule", err)
+	}
+	rule := persiandate.Rule{Start: jalali(1402, 12, 30), Freq: persiandate.Daily}
+	if _, err := pd.OccurrencesE(rule, jalali(1403, 1, 1), jalali(1403, 2, 1)); !errors.Is(err, persiandate.ErrInvalidDay) {
+		t.Errorf("OccurrencesE starting on 1402-12-30 error = %v, expected ErrInvalidDay", err)
+	}
+}
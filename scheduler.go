@@ -0,0 +1,248 @@
+package persiandate
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// Schedule gives the instants a scheduled job runs at. *Cron and the
+// schedule returned by StartOfEach implement it.
+type Schedule interface {
+	// Next returns the first instant after t, or the zero Time if there is
+	// none. A Scheduler stops a job whose Next returns an instant not after t.
+	Next(t time.Time) time.Time
+}
+
+// unitStarts is the schedule returned by StartOfEach
+type unitStarts struct {
+	p    *PersianDate
+	unit Unit
+	loc  *time.Location
+}
+
+// StartOfEach returns a schedule of midnight at the start of each Jalali day,
+// week, month, season or year in the location of p, Asia/Tehran by default.
+// If midnight does not exist because of a daylight saving change, it is the
+// first instant of the day.
+func (p *PersianDate) StartOfEach(unit Unit) Schedule {
+	s, err := p.StartOfEachE(unit)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// StartOfEachE is like StartOfEach but returns an error instead of panicking
+func (p *PersianDate) StartOfEachE(unit Unit) (Schedule, error) {
+	if unit < UnitDay || unit > UnitYear {
+		return nil, invalidUnit(int(unit))
+	}
+	loc, err := p.loadLocation()
+	if err != nil && !errors.Is(err, ErrBuiltinLocation) {
+		return nil, err
+	}
+	return unitStarts{p: p, unit: unit, loc: loc}, nil
+}
+
+// Next returns the start of the first unit after the one containing t
+func (s unitStarts) Next(t time.Time) time.Time {
+	t = t.In(s.loc)
+	d, err := s.p.julianDayToJalali(int(JDNFromTime(t)))
+	if err != nil {
+		return time.Time{}
+	}
+	_, end, err := s.p.boundsOf(d, s.unit)
+	if err != nil {
+		return time.Time{}
+	}
+	jdn, err := s.p.jalaliToJulianDay(end.Year, end.Month, end.Day)
+	if err != nil {
+		return time.Time{}
+	}
+	return dayStart(JDN(jdn+1), s.loc)
+}
+
+// CatchUp decides what a Scheduler does when it finds that more than one run
+// of a job is due, because the jobs before it ran late, the machine was
+// suspended or the clock was set forward
+type CatchUp int
+
+const (
+	// CatchUpLatest runs the job once, for the latest instant that is due
+	CatchUpLatest CatchUp = iota
+	// CatchUpAll runs the job once for every instant that is due, in order
+	CatchUpAll
+)
+
+// Scheduler runs functions at the instants of their schedules, reading the
+// time from the clock of the PersianDate it was created with.
+//
+// Jobs run one at a time on the goroutine that called Run. Runs that are
+// missed are caught up according to the CatchUp policy. When the clock is set
+// back, runs that already happened are not repeated: a job next runs when
+// the clock reaches the instant it was waiting for.
+type Scheduler struct {
+	p      *PersianDate
+	policy CatchUp
+
+	mu    sync.Mutex
+	jobs  []*scheduledJob
+	added chan struct{} // wakes Run when a job is added
+}
+
+// scheduledJob is a function added to a Scheduler and its next instant
+type scheduledJob struct {
+	schedule Schedule
+	fn       func(ctx context.Context, at time.Time)
+	next     time.Time
+	last     time.Time // when non-zero, next is computed from it instead of the time Run starts
+}
+
+// schedulerMaxWait is the longest the scheduler sleeps before reading the
+// clock again, so that a change of the system clock is noticed
+const schedulerMaxWait = time.Minute
+
+// NewScheduler returns a Scheduler using the clock of p
+func (p *PersianDate) NewScheduler(policy CatchUp) *Scheduler {
+	return &Scheduler{p: p, policy: policy, added: make(chan struct{}, 1)}
+}
+
+// Add schedules fn to run at every instant of schedule from the time Run
+// starts, or from now if it is already running. fn receives the context
+// given to Run and the instant it was scheduled for.
+func (s *Scheduler) Add(schedule Schedule, fn func(ctx context.Context, at time.Time)) {
+	s.AddFrom(schedule, time.Time{}, fn)
+}
+
+// AddFrom is like Add for a job that last ran at last, typically read back
+// from storage: the instants since last are due as soon as Run starts and
+// are caught up according to the CatchUp policy.
+func (s *Scheduler) AddFrom(schedule Schedule, last time.Time, fn func(ctx context.Context, at time.Time)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.jobs = append(s.jobs, &scheduledJob{schedule: schedule, fn: fn, last: last})
+	select {
+	case s.added <- struct{}{}:
+	default:
+	}
+}
+
+// Run runs the jobs until ctx is done, then returns ctx.Err(). A job that is
+// running when ctx is done is not interrupted; Run returns once it does.
+func (s *Scheduler) Run(ctx context.Context) error {
+	// The jobs added so far are seen below
+	select {
+	case <-s.added:
+	default:
+	}
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		now := s.p.now()
+
+		job, wake := s.due(now)
+		if job != nil {
+			s.run(ctx, job, now)
+			continue
+		}
+
+		wait := schedulerMaxWait
+		if !wake.IsZero() && wake.Sub(now) < wait {
+			wait = wake.Sub(now)
+		}
+		timer, stop := s.after(wait)
+		select {
+		case <-ctx.Done():
+			stop()
+			return ctx.Err()
+		case <-s.added:
+			// Woken early: release the timer so it does not count as a sleeper
+			stop()
+		case <-timer:
+		}
+	}
+}
+
+// due returns the job whose next instant is the earliest one not after now,
+// or else the earliest next instant of all jobs
+func (s *Scheduler) due(now time.Time) (*scheduledJob, time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var earliest *scheduledJob
+	for _, job := range s.jobs {
+		if job.next.IsZero() && job.schedule != nil {
+			from := now
+			if !job.last.IsZero() {
+				from = job.last
+			}
+			job.next = job.schedule.Next(from)
+			if !job.next.After(from) {
+				// The schedule has ended, or would never move past from
+				job.next = time.Time{}
+				job.schedule = nil
+			}
+		}
+		if job.next.IsZero() {
+			continue
+		}
+		if earliest == nil || job.next.Before(earliest.next) {
+			earliest = job
+		}
+	}
+	if earliest == nil {
+		return nil, time.Time{}
+	}
+	if earliest.next.After(now) {
+		return nil, earliest.next
+	}
+	return earliest, earliest.next
+}
+
+// run runs job for the instants that are due at now
+func (s *Scheduler) run(ctx context.Context, job *scheduledJob, now time.Time) {
+	s.mu.Lock()
+	var instants []time.Time
+	at := job.next
+	for !at.IsZero() && !at.After(now) {
+		if s.policy == CatchUpAll || len(instants) == 0 {
+			instants = append(instants, at)
+		} else {
+			instants[0] = at
+		}
+		next := job.schedule.Next(at)
+		if !next.After(at) {
+			// The schedule has ended, or would never move past at
+			next = time.Time{}
+		}
+		at = next
+	}
+	if job.next = at; at.IsZero() {
+		job.schedule = nil
+	}
+	s.mu.Unlock()
+
+	for _, at := range instants {
+		if ctx.Err() != nil {
+			return
+		}
+		job.fn(ctx, at)
+	}
+}
+
+// after waits on the clock of p when it can, and on a system timer if not.
+// It returns the channel to wait on and a function that stops waiting.
+func (s *Scheduler) after(d time.Duration) (<-chan time.Time, func()) {
+	switch c := s.p.clock.(type) {
+	case *FakeClock:
+		timer := c.NewTimer(d)
+		return timer.C, func() { timer.Stop() }
+	case waitClock:
+		return c.After(d), func() {}
+	}
+	timer := time.NewTimer(d)
+	return timer.C, func() { timer.Stop() }
+}
@@ -0,0 +1,332 @@
+package persiandate_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	persiandate "github.com/NothingMotion/PersianDate"
+)
+
+func TestStartOfEach(t *testing.T) {
+	tehran := persiandate.BuiltinLocation("Asia/Tehran")
+	pd := persiandate.New("", persiandate.WithLocation(tehran))
+
+	tests := []struct {
+		unit     persiandate.Unit
+		from     time.Time
+		expected time.Time
+	}{
+		// 2025-03-20 is 1403-12-30, a Thursday
+		{persiandate.UnitDay, time.Date(2025, 3, 20, 12, 0, 0, 0, tehran), time.Date(2025, 3, 21, 0, 0, 0, 0, tehran)},
+		{persiandate.UnitWeek, time.Date(2025, 3, 20, 12, 0, 0, 0, tehran), time.Date(2025, 3, 22, 0, 0, 0, 0, tehran)},
+		{persiandate.UnitMonth, time.Date(2025, 3, 20, 12, 0, 0, 0, tehran), time.Date(2025, 3, 21, 0, 0, 0, 0, tehran)},
+		{persiandate.UnitSeason, time.Date(2025, 3, 20, 12, 0, 0, 0, tehran), time.Date(2025, 3, 21, 0, 0, 0, 0, tehran)},
+		{persiandate.UnitYear, time.Date(2025, 3, 20, 12, 0, 0, 0, tehran), time.Date(2025, 3, 21, 0, 0, 0, 0, tehran)},
+		{persiandate.UnitMonth, time.Date(2025, 3, 21, 0, 0, 0, 0, tehran), time.Date(2025, 4, 21, 0, 0, 0, 0, tehran)},
+		{persiandate.UnitSeason, time.Date(2025, 3, 21, 0, 0, 0, 0, tehran), time.Date(2025, 6, 22, 0, 0, 0, 0, tehran)},
+		{persiandate.UnitYear, time.Date(2025, 3, 21, 0, 0, 0, 0, tehran), time.Date(2026, 3, 21, 0, 0, 0, 0, tehran)},
+		// Clocks moved from 00:00 to 01:00 on 2 Farvardin 1400
+		{persiandate.UnitDay, time.Date(2021, 3, 21, 12, 0, 0, 0, tehran), time.Date(2021, 3, 21, 20, 30, 0, 0, time.UTC)},
+	}
+
+	for _, test := range tests {
+		if got := pd.StartOfEach(test.unit).Next(test.from); !got.Equal(test.expected) {
+			t.Errorf("StartOfEach(%v).Next(%v) = %v, expected %v", test.unit, test.from, got, test.expected)
+		}
+	}
+
+	if _, err := pd.StartOfEachE(persiandate.Unit(9)); !errors.Is(err, persiandate.ErrInvalidUnit) {
+		t.Errorf("StartOfEachE(Unit(9)) error = %v, expected ErrInvalidUnit", err)
+	}
+}
+
+// startScheduler runs s until the test ends and returns a function that
+// cancels it and returns the error of Run
+func startScheduler(t *testing.T, s *persiandate.Scheduler) func() error {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- s.Run(ctx) }()
+	t.Cleanup(cancel)
+	return func() error {
+		cancel()
+		select {
+		case err := <-done:
+			return err
+		case <-time.After(5 * time.Second):
+			t.Fatal("Run did not return after cancel")
+			return nil
+		}
+	}
+}
+
+// waitForSleep waits until the scheduler is waiting on the clock
+func waitForSleep(t *testing.T, clock *persiandate.FakeClock) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for clock.Waiters() == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("scheduler did not go to sleep")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+// expectRuns checks that exactly the expected instants were run
+func expectRuns(t *testing.T, runs <-chan time.Time, expected ...time.Time) {
+	t.Helper()
+	for _, want := range expected {
+		select {
+		case got := <-runs:
+			if !got.Equal(want) {
+				t.Errorf("run at %v, expected %v", got, want)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("no run, expected %v", want)
+		}
+	}
+	select {
+	case got := <-runs:
+		t.Errorf("unexpected run at %v", got)
+	default:
+	}
+}
+
+func TestSchedulerRunsAtDayStarts(t *testing.T) {
+	tehran := persiandate.BuiltinLocation("Asia/Tehran")
+	clock := persiandate.NewFakeClock(time.Date(2025, 3, 20, 22, 0, 0, 0, tehran))
+	pd := persiandate.New("", persiandate.WithClock(clock), persiandate.WithLocation(tehran))
+
+	runs := make(chan time.Time, 10)
+	s := pd.NewScheduler(persiandate.CatchUpLatest)
+	s.Add(pd.StartOfEach(persiandate.UnitDay), func(ctx context.Context, at time.Time) { runs <- at })
+	stop := startScheduler(t, s)
+
+	waitForSleep(t, clock)
+	expectRuns(t, runs)
+	clock.Advance(2 * time.Hour)
+	waitForSleep(t, clock)
+	expectRuns(t, runs, time.Date(2025, 3, 21, 0, 0, 0, 0, tehran))
+
+	// A clock set forward is noticed within a minute and runs the latest
+	// missed day once
+	clock.Set(time.Date(2025, 3, 24, 6, 0, 0, 0, tehran))
+	waitForSleep(t, clock)
+	expectRuns(t, runs)
+	clock.Advance(time.Minute)
+	waitForSleep(t, clock)
+	expectRuns(t, runs, time.Date(2025, 3, 24, 0, 0, 0, 0, tehran))
+
+	// A clock set back does not repeat the runs
+	clock.Set(time.Date(2025, 3, 22, 23, 58, 0, 0, tehran))
+	for i := 0; i < 3; i++ {
+		clock.Advance(time.Minute)
+		waitForSleep(t, clock)
+	}
+	expectRuns(t, runs)
+	clock.Set(time.Date(2025, 3, 24, 23, 59, 0, 0, tehran))
+	clock.Advance(time.Minute)
+	waitForSleep(t, clock)
+	expectRuns(t, runs, time.Date(2025, 3, 25, 0, 0, 0, 0, tehran))
+
+	if err := stop(); !errors.Is(err, context.Canceled) {
+		t.Errorf("Run returned %v, expected context.Canceled", err)
+	}
+}
+
+func TestSchedulerCatchUpAll(t *testing.T) {
+	tehran := persiandate.BuiltinLocation("Asia/Tehran")
+	clock := persiandate.NewFakeClock(time.Date(2025, 3, 21, 12, 0, 0, 0, tehran))
+	pd := persiandate.New("", persiandate.WithClock(clock), persiandate.WithLocation(tehran))
+
+	runs := make(chan time.Time, 10)
+	s := pd.NewScheduler(persiandate.CatchUpAll)
+	last := time.Date(2025, 3, 18, 0, 0, 0, 0, tehran)
+	s.AddFrom(pd.StartOfEach(persiandate.UnitDay), last, func(ctx context.Context, at time.Time) { runs <- at })
+	startScheduler(t, s)
+
+	waitForSleep(t, clock)
+	expectRuns(t, runs,
+		time.Date(2025, 3, 19, 0, 0, 0, 0, tehran),
+		time.Date(2025, 3, 20, 0, 0, 0, 0, tehran),
+		time.Date(2025, 3, 21, 0, 0, 0, 0, tehran))
+}
+
+func TestSchedulerCron(t *testing.T) {
+	tehran := persiandate.BuiltinLocation("Asia/Tehran")
+	clock := persiandate.NewFakeClock(time.Date(2025, 4, 20, 22, 59, 30, 0, tehran))
+	pd := persiandate.New("", persiandate.WithClock(clock), persiandate.WithLocation(tehran))
+
+	monthEnd, err := pd.ParseCron("0 23 L * *")
+	if err != nil {
+		t.Fatalf("ParseCron returned error: %v", err)
+	}
+	runs := make(chan time.Time, 10)
+	s := pd.NewScheduler(persiandate.CatchUpLatest)
+	s.Add(monthEnd, func(ctx context.Context, at time.Time) { runs <- at })
+	s.Add(pd.StartOfEach(persiandate.UnitMonth), func(ctx context.Context, at time.Time) { runs <- at })
+	startScheduler(t, s)
+
+	// 1404-01-31 23:00, then 1404-02-01 00:00
+	waitForSleep(t, clock)
+	clock.Advance(30 * time.Second)
+	waitForSleep(t, clock)
+	expectRuns(t, runs, time.Date(2025, 4, 20, 23, 0, 0, 0, tehran))
+	clock.Advance(time.Hour)
+	waitForSleep(t, clock)
+	expectRuns(t, runs, time.Date(2025, 4, 21, 0, 0, 0, 0, tehran))
+}
+
+func TestSchedulerGracefulShutdown(t *testing.T) {
+	tehran := persiandate.BuiltinLocation("Asia/Tehran")
+	clock := persiandate.NewFakeClock(time.Date(2025, 3, 20, 23, 0, 0, 0, tehran))
+	pd := persiandate.New("", persiandate.WithClock(clock), persiandate.WithLocation(tehran))
+
+	started := make(chan struct{})
+	finished := make(chan struct{})
+	s := pd.NewScheduler(persiandate.CatchUpLatest)
+	s.Add(pd.StartOfEach(persiandate.UnitDay), func(ctx context.Context, at time.Time) {
+		close(started)
+		<-ctx.Done()
+		close(finished)
+	})
+	stop := startScheduler(t, s)
+
+	waitForSleep(t, clock)
+	clock.Advance(time.Hour)
+	<-started
+	if err := stop(); !errors.Is(err, context.Canceled) {
+		t.Errorf("Run returned %v, expected context.Canceled", err)
+	}
+	select {
+	case <-finished:
+	default:
+		t.Errorf("Run returned before the running job")
+	}
+}
+
+// TestSchedulerWestOfUTC runs day starts in Havana, where midnight of
+// 12 March 2023 (1401-12-21) is skipped and time.Date resolves it to the
+// evening before
+func TestSchedulerWestOfUTC(t *testing.T) {
+	havana, err := time.LoadLocation("America/Havana")
+	if err != nil {
+		t.Skipf("system zoneinfo for America/Havana unavailable: %v", err)
+	}
+	pd := persiandate.New("", persiandate.WithLocation(havana))
+
+	from := time.Date(2023, 3, 11, 23, 1, 0, 0, havana)
+	firstInstant := time.Date(2023, 3, 12, 1, 0, 0, 0, havana)
+	if got := pd.StartOfEach(persiandate.UnitDay).Next(from); !got.Equal(firstInstant) {
+		t.Errorf("StartOfEach(UnitDay).Next(%v) = %v, expected %v", from, got, firstInstant)
+	}
+
+	clock := persiandate.NewFakeClock(from)
+	pd = persiandate.New("", persiandate.WithClock(clock), persiandate.WithLocation(havana))
+	runs := make(chan time.Time, 10)
+	s := pd.NewScheduler(persiandate.CatchUpLatest)
+	s.Add(pd.StartOfEach(persiandate.UnitDay), func(ctx context.Context, at time.Time) { runs <- at })
+	stop := startScheduler(t, s)
+
+	waitForSleep(t, clock)
+	expectRuns(t, runs)
+	clock.Advance(time.Hour)
+	waitForSleep(t, clock)
+	expectRuns(t, runs, firstInstant)
+	if err := stop(); !errors.Is(err, context.Canceled) {
+		t.Errorf("Run returned %v, expected context.Canceled", err)
+	}
+}
+
+// stuckSchedule breaks the Schedule contract by never moving past at
+type stuckSchedule struct {
+	at time.Time
+}
+
+func (s stuckSchedule) Next(t time.Time) time.Time { return s.at }
+
+func TestSchedulerStopsStuckSchedule(t *testing.T) {
+	clock := persiandate.NewFakeClock(time.Date(2025, 3, 21, 12, 0, 0, 0, time.UTC))
+	pd := persiandate.New("", persiandate.WithClock(clock), persiandate.WithLocation(time.UTC))
+
+	runs := make(chan time.Time, 10)
+	s := pd.NewScheduler(persiandate.CatchUpAll)
+	last := time.Date(2025, 3, 21, 10, 0, 0, 0, time.UTC)
+	stuck := time.Date(2025, 3, 21, 11, 0, 0, 0, time.UTC)
+	s.AddFrom(stuckSchedule{at: stuck}, last, func(ctx context.Context, at time.Time) { runs <- at })
+	s.AddFrom(stuckSchedule{at: last}, last, func(ctx context.Context, at time.Time) { runs <- at })
+	s.Add(pd.StartOfEach(persiandate.UnitDay), func(ctx context.Context, at time.Time) { runs <- at })
+	stop := startScheduler(t, s)
+
+	// The first job runs once and is stopped, the second never runs
+	waitForSleep(t, clock)
+	expectRuns(t, runs, stuck)
+	if err := stop(); !errors.Is(err, context.Canceled) {
+		t.Errorf("Run returned %v, expected context.Canceled", err)
+	}
+}
+
+func TestSchedulerReleasesTimer(t *testing.T) {
+	tehran := persiandate.BuiltinLocation("Asia/Tehran")
+	clock := persiandate.NewFakeClock(time.Date(2025, 3, 20, 12, 0, 0, 0, tehran))
+	pd := persiandate.New("", persiandate.WithClock(clock), persiandate.WithLocation(tehran))
+
+	runs := make(chan time.Time, 10)
+	s := pd.NewScheduler(persiandate.CatchUpLatest)
+	s.Add(pd.StartOfEach(persiandate.UnitDay), func(ctx context.Context, at time.Time) { runs <- at })
+	stop := startScheduler(t, s)
+	waitForSleep(t, clock)
+
+	// A job added while Run sleeps wakes it; the timer it slept on must not
+	// be left behind
+	last := time.Date(2025, 3, 19, 0, 0, 0, 0, tehran)
+	s.AddFrom(pd.StartOfEach(persiandate.UnitDay), last, func(ctx context.Context, at time.Time) { runs <- at })
+	expectRuns(t, runs, time.Date(2025, 3, 20, 0, 0, 0, 0, tehran))
+	waitForSleep(t, clock)
+	if n := clock.Waiters(); n != 1 {
+		t.Errorf("Waiters() = %d after a job was added, expected 1", n)
+	}
+
+	stop()
+	if n := clock.Waiters(); n != 0 {
+		t.Errorf("Waiters() = %d after Run returned, expected 0", n)
+	}
+}
+
+func TestFakeClockAfter(t *testing.T) {
+	clock := persiandate.NewFakeClock(time.Date(2025, 3, 21, 0, 0, 0, 0, time.UTC))
+	c := clock.After(time.Minute)
+	if clock.Waiters() != 1 {
+		t.Fatalf("Waiters() = %d, expected 1", clock.Waiters())
+	}
+	clock.Advance(59 * time.Second)
+	clock.Set(clock.Now().Add(time.Hour))
+	select {
+	case <-c:
+		t.Fatal("After fired before its time")
+	default:
+	}
+	clock.Advance(time.Second)
+	if got := <-c; !got.Equal(time.Date(2025, 3, 21, 1, 1, 0, 0, time.UTC)) || clock.Waiters() != 0 {
+		t.Errorf("After fired with %v and %d waiters left", got, clock.Waiters())
+	}
+	if got := <-clock.After(0); !got.Equal(clock.Now()) {
+		t.Errorf("After(0) = %v, expected the current time", got)
+	}
+
+	timer := clock.NewTimer(time.Minute)
+	if !timer.Stop() || clock.Waiters() != 0 {
+		t.Errorf("Stop() should release the timer, %d waiters left", clock.Waiters())
+	}
+	clock.Advance(time.Minute)
+	select {
+	case <-timer.C:
+		t.Error("stopped timer fired")
+	default:
+	}
+	if timer.Stop() {
+		t.Error("Stop() of a stopped timer returned true")
+	}
+}
@@ -0,0 +1,62 @@
+package persiandate
+
+import (
+	"strconv"
+	"time"
+)
+
+// Season numbers the seasons from 1 (spring) to 4 (winter), like the "b"
+// format token and GetSeason
+type Season int
+
+const (
+	Spring Season = iota + 1 // بهار, starts at the March equinox (Nowruz)
+	Summer                   // تابستان, starts at the June solstice
+	Autumn                   // پاییز, starts at the September equinox
+	Winter                   // زمستان, starts at the December solstice
+)
+
+func (s Season) String() string {
+	switch s {
+	case Spring:
+		return "Spring"
+	case Summer:
+		return "Summer"
+	case Autumn:
+		return "Autumn"
+	case Winter:
+		return "Winter"
+	}
+	return "Season(" + strconv.Itoa(int(s)) + ")"
+}
+
+// SeasonStart returns the moment, in UTC, of the equinox or solstice that
+// starts the season s of the Jalali year jy. The start of Spring is the
+// moment of the new year (tahvil-e sal). Call In on the result to show it in
+// another location, or pass it to DateTimeOf to format it as a Jalali date.
+//
+// It is computed offline with the same method as the Astronomical leap rule,
+// accurate to about a minute, for the years -1621 to 2379.
+func SeasonStart(jy int, s Season) (time.Time, error) {
+	if jy < -1621 || jy > 2379 {
+		return time.Time{}, yearOutOfRange("Jalali", jy)
+	}
+	if s < Spring || s > Winter {
+		return time.Time{}, invalidSeason(int(s))
+	}
+	return JulianDate(seasonStart(jy+621, s)).Time(), nil
+}
+
+// SeasonStarts returns the start of the four seasons of the Jalali year jy,
+// see SeasonStart
+func SeasonStarts(jy int) ([4]time.Time, error) {
+	var starts [4]time.Time
+	for s := Spring; s <= Winter; s++ {
+		t, err := SeasonStart(jy, s)
+		if err != nil {
+			return starts, err
+		}
+		starts[s-1] = t
+	}
+	return starts, nil
+}
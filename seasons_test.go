@@ -0,0 +1,70 @@
+package persiandate_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	persiandate "github.com/NothingMotion/PersianDate"
+)
+
+func TestSeasonStarts(t *testing.T) {
+	// Equinoxes and solstices published by the US Naval Observatory, to the minute
+	tests := []struct {
+		year     int
+		expected [4]string
+	}{
+		{1379, [4]string{"2000-03-20 07:35", "2000-06-21 01:48", "2000-09-22 17:27", "2000-12-21 13:37"}},
+		{1403, [4]string{"2024-03-20 03:06", "2024-06-20 20:51", "2024-09-22 12:44", "2024-12-21 09:21"}},
+		{1404, [4]string{"2025-03-20 09:01", "2025-06-21 02:42", "2025-09-22 18:19", "2025-12-21 15:03"}},
+		{1405, [4]string{"2026-03-20 14:46", "2026-06-21 08:24", "2026-09-23 00:05", "2026-12-21 20:50"}},
+	}
+
+	for _, test := range tests {
+		starts, err := persiandate.SeasonStarts(test.year)
+		if err != nil {
+			t.Errorf("SeasonStarts(%d) returned error: %v", test.year, err)
+			continue
+		}
+		for i, s := range starts {
+			expected, _ := time.Parse("2006-01-02 15:04", test.expected[i])
+			if d := s.Sub(expected).Abs(); d > 90*time.Second {
+				t.Errorf("%v %d = %v, expected %s", persiandate.Season(i+1), test.year, s, test.expected[i])
+			}
+		}
+	}
+}
+
+func TestSeasonStartFormat(t *testing.T) {
+	pd := persiandate.New("YYYY/MM/DD HH:ii")
+
+	tests := []struct {
+		year     int
+		season   persiandate.Season
+		location string
+		expected string
+	}{
+		{1403, persiandate.Spring, "Asia/Tehran", "1403/01/01 06:36"},
+		{1404, persiandate.Spring, "Asia/Tehran", "1403/12/30 12:31"}, // after noon, so Nowruz is the next day
+		{1403, persiandate.Winter, "Asia/Kabul", "1403/10/01 13:50"},
+	}
+
+	for _, test := range tests {
+		start, err := persiandate.SeasonStart(test.year, test.season)
+		if err != nil {
+			t.Errorf("SeasonStart(%d, %v) returned error: %v", test.year, test.season, err)
+			continue
+		}
+		local := start.In(persiandate.BuiltinLocation(test.location))
+		if got := pd.Format(pd.DateTimeOf(local).JalaliDate); got != test.expected {
+			t.Errorf("%v %d in %s = %s, expected %s", test.season, test.year, test.location, got, test.expected)
+		}
+	}
+
+	if _, err := persiandate.SeasonStart(1403, 5); !errors.Is(err, persiandate.ErrInvalidSeason) {
+		t.Errorf("SeasonStart(1403, 5) error = %v, expected ErrInvalidSeason", err)
+	}
+	if _, err := persiandate.SeasonStarts(2380); !errors.Is(err, persiandate.ErrYearOutOfRange) {
+		t.Errorf("SeasonStarts(2380) error = %v, expected ErrYearOutOfRange", err)
+	}
+}
@@ -0,0 +1,132 @@
+package persiandate
+
+import (
+	"cmp"
+	"slices"
+)
+
+// DayNumberer is implemented by the date types that can be placed on the
+// Julian day line: JalaliDate, JalaliDateTime, GregorianDate,
+// PersianDateResponse, GregorianDateResponse, Time and JDN. It is the constraint
+// of the generic Sort, Min, Max and Filter functions.
+type DayNumberer interface {
+	JDN() (JDN, error)
+}
+
+// JDN returns the Julian Day Number of the Jalali date j
+func (j JalaliDate) JDN() (JDN, error) {
+	return JDNFromJalali(j.Year, j.Month, j.Day)
+}
+
+// JDN returns the Julian Day Number of the Gregorian date g
+func (g GregorianDate) JDN() (JDN, error) {
+	return JDNFromGregorian(g.Year, g.Month, g.Day)
+}
+
+// JDN returns the Julian Day Number of the Jalali date in p
+func (p PersianDateResponse) JDN() (JDN, error) {
+	return JDNFromJalali(p.Year, p.Month, p.Day)
+}
+
+// JDN returns the Julian Day Number of the Gregorian date in g
+func (g GregorianDateResponse) JDN() (JDN, error) {
+	return JDNFromGregorian(g.Year, g.Month, g.Day)
+}
+
+// JDN returns the Julian Day Number of the date of t in its location
+func (t Time) JDN() (JDN, error) {
+	return JDNFromTime(t.t), nil
+}
+
+// Sort returns the dates ordered from oldest to newest. Equal dates keep
+// their input order. Dates that cannot be converted are left out of the
+// result and listed in the returned *RejectedError.
+func Sort[T DayNumberer](dates []T) ([]T, error) {
+	keys, err := julianDays(dates)
+	sortJulianDays(keys)
+
+	sorted := make([]T, len(keys))
+	for i, k := range keys {
+		sorted[i] = dates[k.index]
+	}
+	return sorted, err
+}
+
+// Min returns the oldest of the dates, the first one if several are equal.
+// Rejected dates are skipped and reported like in Sort.
+func Min[T DayNumberer](dates []T) (T, error) {
+	keys, err := julianDays(dates)
+	var zero T
+	if len(keys) == 0 {
+		return zero, err
+	}
+	return dates[pickJulianDay(keys, true)], err
+}
+
+// Max returns the newest of the dates, the last one if several are equal.
+// Rejected dates are skipped and reported like in Sort.
+func Max[T DayNumberer](dates []T) (T, error) {
+	keys, err := julianDays(dates)
+	var zero T
+	if len(keys) == 0 {
+		return zero, err
+	}
+	return dates[pickJulianDay(keys, false)], err
+}
+
+// Filter returns the sorted dates for which keep returns true.
+// Rejected dates are skipped and reported like in Sort.
+func Filter[T DayNumberer](dates []T, keep func(T) bool) ([]T, error) {
+	sorted, err := Sort(dates)
+	filtered := sorted[:0]
+	for _, d := range sorted {
+		if keep(d) {
+			filtered = append(filtered, d)
+		}
+	}
+	return filtered, err
+}
+
+// julianDayIndex is the cached Julian day number of dates[index]
+type julianDayIndex struct {
+	jdn   int
+	index int
+}
+
+// julianDays computes the Julian day of every date once, collecting the
+// dates that fail in a *RejectedError
+func julianDays[T DayNumberer](dates []T) ([]julianDayIndex, error) {
+	keys := make([]julianDayIndex, 0, len(dates))
+	var rejected RejectedError
+	for i, d := range dates {
+		jdn, err := d.JDN()
+		if err != nil {
+			rejected.add(i, d, err)
+			continue
+		}
+		keys = append(keys, julianDayIndex{jdn: int(jdn), index: i})
+	}
+	return keys, rejected.err()
+}
+
+// sortJulianDays sorts the keys by Julian day. Breaking ties on the input
+// index makes the faster unstable sort stable.
+func sortJulianDays(keys []julianDayIndex) {
+	slices.SortFunc(keys, func(a, b julianDayIndex) int {
+		if c := cmp.Compare(a.jdn, b.jdn); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.index, b.index)
+	})
+}
+
+// pickJulianDay returns the input index of the first smallest or last largest key
+func pickJulianDay(keys []julianDayIndex, isMin bool) int {
+	best := keys[0]
+	for _, k := range keys[1:] {
+		if (isMin && k.jdn < best.jdn) || (!isMin && k.jdn >= best.jdn) {
+			best = k
+		}
+	}
+	return best.index
+}
@@ -0,0 +1,47 @@
+package persiandate_test
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	persiandate "github.com/NothingMotion/PersianDate"
+)
+
+func randomDates(n int) []persiandate.JalaliDate {
+	r := rand.New(rand.NewSource(1))
+	dates := make([]persiandate.JalaliDate, n)
+	for i := range dates {
+		dates[i] = jalali(1300+r.Intn(200), 1+r.Intn(12), 1+r.Intn(29))
+	}
+	return dates
+}
+
+func BenchmarkSort(b *testing.B) {
+	for _, n := range []int{10_000, 1_000_000} {
+		dates := randomDates(n)
+		args := make([]interface{}, n)
+		for i, d := range dates {
+			args[i] = d
+		}
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			pd := persiandate.New("")
+			for i := 0; i < b.N; i++ {
+				pd.Sort(args...)
+			}
+		})
+	}
+}
+
+func BenchmarkMinMax(b *testing.B) {
+	for _, n := range []int{10_000, 1_000_000} {
+		dates := randomDates(n)
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			pd := persiandate.New("")
+			for i := 0; i < b.N; i++ {
+				pd.Min(dates)
+				pd.Max(dates)
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package persiandate_test
 
 import (
+	"errors"
 	"testing"
 	"time"
 
@@ -133,3 +134,133 @@ func TestFilter(t *testing.T) {
 		t.Errorf("Filter() for month 1 did not return expected dates")
 	}
 }
+
+func TestSortIsStable(t *testing.T) {
+	pd := persiandate.New("")
+
+	// Same day with different clocks: Sort must keep the input order.
+	morning := persiandate.JalaliDate{Date: persiandate.Date{Year: 1402, Month: 6, Day: 15, Hour: 9}}
+	evening := persiandate.JalaliDate{Date: persiandate.Date{Year: 1402, Month: 6, Day: 15, Hour: 21}}
+	earlier := persiandate.JalaliDate{Date: persiandate.Date{Year: 1401, Month: 1, Day: 1}}
+
+	sorted := pd.Sort(evening, earlier, morning)
+	if sorted[0] != earlier || sorted[1] != evening || sorted[2] != morning {
+		t.Errorf("Sort() = %v, expected stable order [earlier evening morning]", sorted)
+	}
+
+	if got := pd.Min([]persiandate.JalaliDate{evening, morning}); got != evening {
+		t.Errorf("Min() = %+v, expected the first of equal dates", got)
+	}
+	if got := pd.Max([]persiandate.JalaliDate{evening, morning}); got != morning {
+		t.Errorf("Max() = %+v, expected the last of equal dates", got)
+	}
+	if got := pd.Max(evening, earlier, morning); got != morning {
+		t.Errorf("Max() variadic = %+v, expected the last of equal dates", got)
+	}
+}
+
+func TestSortRejectedInputs(t *testing.T) {
+	pd := persiandate.New("")
+
+	date := persiandate.JalaliDate{Date: persiandate.Date{Year: 1402, Month: 6, Day: 15}}
+	pointer := &persiandate.JalaliDate{Date: persiandate.Date{Year: 1401, Month: 1, Day: 1}}
+	gregorian := persiandate.GregorianDate{Date: persiandate.Date{Year: 2023, Month: 3, Day: 21}} // 1402-01-01
+	response := pd.FromTimeFull(time.Date(2024, 3, 20, 0, 0, 0, 0, time.UTC))                     // 1403-01-01
+
+	sorted, err := pd.SortE(date, 42, pointer, "1402-13-01", gregorian, "not a date", response)
+	expected := []string{"1401-01-01", "1402-01-01", "1402-06-15", "1403-01-01"}
+	if len(sorted) != len(expected) {
+		t.Fatalf("SortE() = %v, expected %v", sorted, expected)
+	}
+	for i, d := range sorted {
+		if d.String() != expected[i] {
+			t.Errorf("SortE()[%d] = %v, expected %s", i, d, expected[i])
+		}
+	}
+
+	var rejected *persiandate.RejectedError
+	if !errors.As(err, &rejected) {
+		t.Fatalf("SortE() error = %v, expected *RejectedError", err)
+	}
+	if len(rejected.Inputs) != 3 || rejected.Inputs[0].Index != 1 || rejected.Inputs[1].Index != 3 || rejected.Inputs[2].Index != 5 {
+		t.Errorf("RejectedError.Inputs = %+v, expected inputs 1, 3 and 5", rejected.Inputs)
+	}
+	if !errors.Is(err, persiandate.ErrUnsupportedType) || !errors.Is(err, persiandate.ErrInvalidMonth) {
+		t.Errorf("SortE() error %v should match ErrUnsupportedType and ErrInvalidMonth", err)
+	}
+
+	if _, err := pd.SortE(); err != nil {
+		t.Errorf("SortE() with no dates returned error: %v", err)
+	}
+
+	// The variants without E skip the rejected inputs, as they always have
+	inputs := []interface{}{date, 42, pointer, "1402-13-01", gregorian, "not a date", response}
+	if got := pd.Sort(inputs...); len(got) != len(expected) || got[0].String() != expected[0] {
+		t.Errorf("Sort() = %v, expected %v", got, expected)
+	}
+	if got := pd.Min(inputs...); got.String() != "1401-01-01" {
+		t.Errorf("Min() = %v, expected 1401-01-01", got)
+	}
+	if got := pd.Max(inputs...); got.String() != "1403-01-01" {
+		t.Errorf("Max() = %v, expected 1403-01-01", got)
+	}
+	keep := func(d persiandate.JalaliDate) bool { return d.Year == 1402 }
+	if got := pd.Filter(keep, inputs...); len(got) != 2 {
+		t.Errorf("Filter() = %v, expected the two dates of 1402", got)
+	}
+}
+
+func TestGenericSort(t *testing.T) {
+	dates := []persiandate.JalaliDate{
+		{Date: persiandate.Date{Year: 1402, Month: 6, Day: 15}},
+		{Date: persiandate.Date{Year: 1402, Month: 12, Day: 30}}, // 1402 is not a leap year
+		{Date: persiandate.Date{Year: 1401, Month: 7, Day: 20}},
+	}
+
+	sorted, err := persiandate.Sort(dates)
+	if len(sorted) != 2 || sorted[0] != dates[2] || sorted[1] != dates[0] {
+		t.Errorf("Sort() = %v, expected [1401-07-20 1402-06-15]", sorted)
+	}
+	var rejected *persiandate.RejectedError
+	if !errors.As(err, &rejected) || len(rejected.Inputs) != 1 || rejected.Inputs[0].Index != 1 {
+		t.Errorf("Sort() error = %v, expected input 1 to be rejected", err)
+	}
+
+	// GregorianDate values are read as Gregorian dates
+	gregorian := []persiandate.GregorianDate{
+		{Date: persiandate.Date{Year: 2024, Month: 3, Day: 20}},
+		{Date: persiandate.Date{Year: 1979, Month: 2, Day: 11}},
+		{Date: persiandate.Date{Year: 2023, Month: 3, Day: 21}},
+	}
+	oldest, err := persiandate.Min(gregorian)
+	if err != nil || oldest != gregorian[1] {
+		t.Errorf("Min() = %v, %v, expected 1979-02-11", oldest, err)
+	}
+	newest, err := persiandate.Max(gregorian)
+	if err != nil || newest != gregorian[0] {
+		t.Errorf("Max() = %v, %v, expected 2024-03-20", newest, err)
+	}
+
+	// Mixed calendars through the interface type
+	mixed := []persiandate.DayNumberer{
+		persiandate.JalaliDate{Date: persiandate.Date{Year: 1402, Month: 1, Day: 2}},
+		persiandate.GregorianDate{Date: persiandate.Date{Year: 2023, Month: 3, Day: 21}}, // 1402-01-01
+		persiandate.TimeOf(time.Date(2023, 3, 20, 12, 0, 0, 0, time.UTC)),                // 1401-12-29
+	}
+	sortedMixed, err := persiandate.Sort(mixed)
+	if err != nil || sortedMixed[0] != mixed[2] || sortedMixed[1] != mixed[1] || sortedMixed[2] != mixed[0] {
+		t.Errorf("Sort(mixed) = %v, %v", sortedMixed, err)
+	}
+
+	in1402, err := persiandate.Filter(mixed, func(d persiandate.DayNumberer) bool {
+		_, isGregorian := d.(persiandate.GregorianDate)
+		return !isGregorian
+	})
+	if err != nil || len(in1402) != 2 || in1402[0] != mixed[2] || in1402[1] != mixed[0] {
+		t.Errorf("Filter(mixed) = %v, %v", in1402, err)
+	}
+
+	if d, err := persiandate.Min([]persiandate.JalaliDate{}); err != nil || !d.IsZero() {
+		t.Errorf("Min(empty) = %v, %v, expected zero date", d, err)
+	}
+}
@@ -0,0 +1,308 @@
+package persiandate
+
+import (
+	"fmt"
+	"time"
+)
+
+// calendar is the shared converter behind the package level types. It is only
+// used for its pure conversion helpers and never has its current date set.
+var calendar = New("")
+
+// timeCalendar is the converter behind Time, see timeRule
+var timeCalendar = New("", WithLeapRule(timeRule{}))
+
+// timeRule is BreakTable continued on both sides by the 33-year cycle of
+// Cycle33, so that every time.Time in the years of Cycle33, the zero value
+// included, has a Jalali date. The cycle is shifted to meet the table
+// at its first and last Nowruz, so no day is skipped or repeated.
+type timeRule struct{}
+
+func (timeRule) YearRange() (int, int) {
+	return Cycle33{}.YearRange()
+}
+
+func (timeRule) Nowruz(jy int) JDN {
+	table, cycle := BreakTable{}, Cycle33{}
+	first, last := table.YearRange()
+	switch {
+	case jy < first:
+		return table.Nowruz(first) - cycle.Nowruz(first) + cycle.Nowruz(jy)
+	case jy > last:
+		end := table.Nowruz(last) + 365
+		if table.IsLeap(last) {
+			end++
+		}
+		return end - cycle.Nowruz(last+1) + cycle.Nowruz(jy)
+	}
+	return table.Nowruz(jy)
+}
+
+func (timeRule) IsLeap(jy int) bool {
+	if first, last := (BreakTable{}).YearRange(); jy < first || jy > last {
+		return Cycle33{}.IsLeap(jy)
+	}
+	return BreakTable{}.IsLeap(jy)
+}
+
+// Time is an immutable instant viewed through the Jalali calendar.
+//
+// It wraps a time.Time, so it keeps the clock, nanoseconds and location of
+// the value it was built from, and every method returns a new Time instead
+// of modifying the receiver. Time values are safe to share between
+// goroutines.
+//
+// Time uses the BreakTable leap rule, continued by the 33-year cycle before
+// the year -61 and after 3177, so it has a Jalali date in every year Cycle33
+// covers. The zero value wraps the zero time.Time, 1 January of the year
+// 1, which is 11 Dey -621.
+type Time struct {
+	t time.Time
+}
+
+// TimeOf returns the Jalali view of t
+func TimeOf(t time.Time) Time {
+	return Time{t: t}
+}
+
+// NewTime returns the Time corresponding to the given Jalali date and clock in loc.
+// Like time.Date, month, day and clock values outside their usual ranges are
+// normalized, so 1402-12-30 becomes 1403-01-01. It panics if loc is nil or the
+// resulting year is outside the years of Cycle33.
+func NewTime(year, month, day, hour, min, sec, nsec int, loc *time.Location) Time {
+	t, err := NewTimeE(year, month, day, hour, min, sec, nsec, loc)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+// NewTimeE is like NewTime but returns an error instead of panicking. It
+// returns ErrNilLocation if loc is nil.
+func NewTimeE(year, month, day, hour, min, sec, nsec int, loc *time.Location) (Time, error) {
+	if loc == nil {
+		return Time{}, ErrNilLocation
+	}
+	jdn, err := normalizedJulianDay(year, month, day)
+	if err != nil {
+		return Time{}, err
+	}
+	g := timeCalendar.julianDayToGregorian(jdn)
+	return Time{t: time.Date(g.Year, time.Month(g.Month), g.Day, hour, min, sec, nsec, loc)}, nil
+}
+
+// normalizedJulianDay returns the Julian day of a Jalali date whose month and
+// day may be outside their usual ranges
+func normalizedJulianDay(year, month, day int) (int, error) {
+	year += floorDiv(month-1, 12)
+	month = floorMod(month-1, 12) + 1
+	jdn, err := timeCalendar.jalaliToJulianDay(year, month, 1)
+	if err != nil {
+		return 0, err
+	}
+	return jdn + day - 1, nil
+}
+
+// floorDiv divides rounding towards negative infinity
+func floorDiv(a, b int) int {
+	q := a / b
+	if (a%b != 0) && ((a < 0) != (b < 0)) {
+		q--
+	}
+	return q
+}
+
+// floorMod returns the remainder of floorDiv, which has the sign of b
+func floorMod(a, b int) int {
+	return a - floorDiv(a, b)*b
+}
+
+// Std returns the wrapped time.Time
+func (t Time) Std() time.Time {
+	return t.t
+}
+
+// Date returns the Jalali date and clock of t. It panics if the year of t is
+// outside the years of Cycle33.
+func (t Time) Date() JalaliDate {
+	d, err := t.DateE()
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+// DateE is like Date but returns an error instead of panicking
+func (t Time) DateE() (JalaliDate, error) {
+	jdn, err := t.julianDay()
+	if err != nil {
+		return JalaliDate{}, err
+	}
+	d, err := timeCalendar.julianDayToJalali(jdn)
+	if err != nil {
+		return JalaliDate{}, err
+	}
+	d.Hour, d.Minute, d.Second = t.t.Clock()
+	return d, nil
+}
+
+// DateTime returns the Jalali date, clock, location and offset of t
+func (t Time) DateTime() JalaliDateTime {
+	_, offset := t.t.Zone()
+	return JalaliDateTime{JalaliDate: t.Date(), Nanosecond: t.t.Nanosecond(), Location: t.t.Location(), Offset: offset}
+}
+
+// julianDay returns the Julian day of the date of t. Years far outside the
+// range of timeCalendar are rejected before they overflow a 32-bit int.
+func (t Time) julianDay() (int, error) {
+	y, m, d := t.t.Date()
+	if first, last := timeCalendar.YearRange(); y < first+621 || y > last+622 {
+		return 0, yearOutOfRange("Jalali", y-621)
+	}
+	return timeCalendar.gregorianToJulianDay(y, int(m), d), nil
+}
+
+// Year returns the Jalali year of t
+func (t Time) Year() int {
+	return t.Date().Year
+}
+
+// Month returns the Jalali month of t, from 1 (Farvardin) to 12 (Esfand)
+func (t Time) Month() int {
+	return t.Date().Month
+}
+
+// Day returns the day of the Jalali month of t
+func (t Time) Day() int {
+	return t.Date().Day
+}
+
+// Weekday returns the day of week of t, from 0 (Saturday) to 6 (Friday)
+func (t Time) Weekday() int {
+	return int((t.t.Weekday() + 1) % 7)
+}
+
+// YearDay returns the day of the Jalali year of t, from 1 to 365 or 366
+func (t Time) YearDay() int {
+	d := t.Date()
+	if d.Month <= 6 {
+		return (d.Month-1)*31 + d.Day
+	}
+	return 186 + (d.Month-7)*30 + d.Day
+}
+
+// Hour returns the hour of t, from 0 to 23
+func (t Time) Hour() int {
+	return t.t.Hour()
+}
+
+// Minute returns the minute of t, from 0 to 59
+func (t Time) Minute() int {
+	return t.t.Minute()
+}
+
+// Second returns the second of t, from 0 to 59
+func (t Time) Second() int {
+	return t.t.Second()
+}
+
+// Nanosecond returns the nanosecond offset within the second of t
+func (t Time) Nanosecond() int {
+	return t.t.Nanosecond()
+}
+
+// Clock returns the hour, minute and second of t
+func (t Time) Clock() (int, int, int) {
+	return t.t.Clock()
+}
+
+// Location returns the location of t
+func (t Time) Location() *time.Location {
+	return t.t.Location()
+}
+
+// In returns t with its location set to loc
+func (t Time) In(loc *time.Location) Time {
+	return Time{t: t.t.In(loc)}
+}
+
+// UTC returns t with its location set to UTC
+func (t Time) UTC() Time {
+	return Time{t: t.t.UTC()}
+}
+
+// Unix returns t as a Unix time
+func (t Time) Unix() int64 {
+	return t.t.Unix()
+}
+
+// IsZero reports whether t wraps the zero time.Time
+func (t Time) IsZero() bool {
+	return t.t.IsZero()
+}
+
+// Add returns t+d
+func (t Time) Add(d time.Duration) Time {
+	return Time{t: t.t.Add(d)}
+}
+
+// AddDate returns t with the given number of Jalali years, months and days
+// added. The clock and location are kept. Like time.Time.AddDate the result
+// is normalized, so adding one month to 31 Shahrivar gives 1 Aban.
+func (t Time) AddDate(years, months, days int) Time {
+	d := t.Date()
+	hour, min, sec := t.t.Clock()
+	return NewTime(d.Year+years, d.Month+months, d.Day+days, hour, min, sec, t.t.Nanosecond(), t.t.Location())
+}
+
+// AddMonths returns t with months Jalali months added, keeping the clock
+// and location. The policy decides what happens when the day does not exist
+// in the target month, see OverflowPolicy.
+func (t Time) AddMonths(months int, policy OverflowPolicy) (Time, error) {
+	d, err := timeCalendar.addMonths(t.Date(), months, policy)
+	if err != nil {
+		return Time{}, err
+	}
+	hour, min, sec := t.t.Clock()
+	return NewTimeE(d.Year, d.Month, d.Day, hour, min, sec, t.t.Nanosecond(), t.t.Location())
+}
+
+// AddYears returns t with years Jalali years added, like AddMonths
+func (t Time) AddYears(years int, policy OverflowPolicy) (Time, error) {
+	return t.AddMonths(12*years, policy)
+}
+
+// Sub returns the duration t-u
+func (t Time) Sub(u Time) time.Duration {
+	return t.t.Sub(u.t)
+}
+
+// Before reports whether t is before u
+func (t Time) Before(u Time) bool {
+	return t.t.Before(u.t)
+}
+
+// After reports whether t is after u
+func (t Time) After(u Time) bool {
+	return t.t.After(u.t)
+}
+
+// Equal reports whether t and u represent the same instant
+func (t Time) Equal(u Time) bool {
+	return t.t.Equal(u.t)
+}
+
+// Compare returns -1 if t is before u, +1 if t is after u and 0 if they are equal
+func (t Time) Compare(u Time) int {
+	return t.t.Compare(u.t)
+}
+
+// String returns t in the form "1402-01-01 15:04:05.999999999 -0700 MST". A
+// time without a Jalali date is written by time.Time.String instead.
+func (t Time) String() string {
+	d, err := t.DateE()
+	if err != nil {
+		return t.t.String()
+	}
+	return fmt.Sprintf("%04d-%02d-%02d %s", d.Year, d.Month, d.Day, t.t.Format("15:04:05.999999999 -0700 MST"))
+}
@@ -0,0 +1,138 @@
+package persiandate_test
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	persiandate "github.com/NothingMotion/PersianDate"
+)
+
+func TestTimeFields(t *testing.T) {
+	tehran := time.FixedZone("+0330", 3*3600+1800)
+	jt := persiandate.TimeOf(time.Date(2024, 3, 19, 18, 45, 30, 500, tehran)) // 1402-12-29
+
+	if jt.Year() != 1402 || jt.Month() != 12 || jt.Day() != 29 {
+		t.Errorf("TimeOf(2024-03-19) = %d-%02d-%02d, expected 1402-12-29", jt.Year(), jt.Month(), jt.Day())
+	}
+	if jt.Weekday() != 3 { // Tuesday
+		t.Errorf("Weekday() = %d, expected 3", jt.Weekday())
+	}
+	if jt.YearDay() != 365 {
+		t.Errorf("YearDay() = %d, expected 365", jt.YearDay())
+	}
+	if h, m, s := jt.Clock(); h != 18 || m != 45 || s != 30 || jt.Nanosecond() != 500 {
+		t.Errorf("Clock() = %d:%d:%d.%d, expected 18:45:30.500", h, m, s, jt.Nanosecond())
+	}
+	if jt.Location() != tehran {
+		t.Errorf("Location() = %v, expected %v", jt.Location(), tehran)
+	}
+	if s := jt.String(); s != "1402-12-29 18:45:30.0000005 +0330 +0330" {
+		t.Errorf("String() = %s", s)
+	}
+}
+
+func TestNewTime(t *testing.T) {
+	jt := persiandate.NewTime(1402, 12, 30, 10, 0, 0, 0, time.UTC) // normalized to 1403-01-01
+	if jt.Year() != 1403 || jt.Month() != 1 || jt.Day() != 1 {
+		t.Errorf("NewTime(1402, 12, 30) = %v, expected 1403-01-01", jt)
+	}
+	if !jt.Std().Equal(time.Date(2024, 3, 20, 10, 0, 0, 0, time.UTC)) {
+		t.Errorf("NewTime(1402, 12, 30).Std() = %v, expected 2024-03-20 10:00", jt.Std())
+	}
+
+	jt = persiandate.NewTime(1403, 0, 1, 0, 0, 0, 0, time.UTC) // month 0 is Esfand of the previous year
+	if jt.Year() != 1402 || jt.Month() != 12 || jt.Day() != 1 {
+		t.Errorf("NewTime(1403, 0, 1) = %v, expected 1402-12-01", jt)
+	}
+
+	if _, err := persiandate.NewTimeE(math.MaxInt, 1, 1, 0, 0, 0, 0, time.UTC); !errors.Is(err, persiandate.ErrYearOutOfRange) {
+		t.Errorf("NewTimeE(math.MaxInt, 1, 1) error = %v, expected ErrYearOutOfRange", err)
+	}
+	if _, err := persiandate.NewTimeE(1403, 1, 1, 0, 0, 0, 0, nil); !errors.Is(err, persiandate.ErrNilLocation) {
+		t.Errorf("NewTimeE with a nil location error = %v, expected ErrNilLocation", err)
+	}
+}
+
+func TestTimeOutsideBreakTable(t *testing.T) {
+	var zero persiandate.Time
+	if s := zero.String(); s != "-621-10-11 00:00:00 +0000 UTC" {
+		t.Errorf("Time{}.String() = %s", s)
+	}
+	if start := zero.StartOf(persiandate.UnitYear); start.Year() != -621 || start.YearDay() != 1 {
+		t.Errorf("Time{}.StartOf(UnitYear) = %v", start)
+	}
+
+	// Every day across both ends of the break table has one date that
+	// converts back to it
+	for _, year := range []int{-61, 3177} {
+		at := persiandate.NewTime(year, 1, 1, 0, 0, 0, 0, time.UTC).AddDate(0, 0, -400)
+		previous := at.Date()
+		for i := 0; i < 1200; i++ {
+			at = persiandate.TimeOf(at.Std().AddDate(0, 0, 1))
+			d := at.Date()
+			if next := persiandate.NewTime(previous.Year, previous.Month, previous.Day+1, 0, 0, 0, 0, time.UTC); !next.Equal(at) {
+				t.Fatalf("day after %v = %v, expected %v", previous, d, next.Date())
+			}
+			if back := persiandate.NewTime(d.Year, d.Month, d.Day, 0, 0, 0, 0, time.UTC); !back.Equal(at) {
+				t.Fatalf("NewTime(%v) = %v, expected %v", d, back, at)
+			}
+			previous = d
+		}
+	}
+
+	huge := persiandate.TimeOf(time.Unix(math.MaxInt64/2, 0).UTC())
+	if s := huge.String(); s != huge.Std().String() {
+		t.Errorf("String() of %v = %s, expected %s", huge.Std(), s, huge.Std())
+	}
+	if _, err := huge.DateE(); !errors.Is(err, persiandate.ErrYearOutOfRange) {
+		t.Errorf("DateE() of %v error = %v, expected ErrYearOutOfRange", huge.Std(), err)
+	}
+}
+
+func TestTimeArithmetic(t *testing.T) {
+	start := persiandate.NewTime(1402, 6, 31, 9, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		years, months, days int
+		expected            string
+	}{
+		{0, 0, 1, "1402-07-01"},
+		{0, 1, 0, "1402-08-01"}, // 31 Mehr does not exist
+		{0, 6, 0, "1403-01-02"}, // Esfand 1402 has 29 days
+		{1, 0, 0, "1403-06-31"},
+		{0, 0, -31, "1402-05-31"},
+	}
+
+	for _, test := range tests {
+		got := start.AddDate(test.years, test.months, test.days)
+		if got.Date().String() != test.expected {
+			t.Errorf("AddDate(%d, %d, %d) = %v, expected %s", test.years, test.months, test.days, got, test.expected)
+		}
+		if got.Hour() != 9 || got.Minute() != 30 {
+			t.Errorf("AddDate(%d, %d, %d) changed the clock to %d:%d", test.years, test.months, test.days, got.Hour(), got.Minute())
+		}
+	}
+
+	if start.Date().String() != "1402-06-31" {
+		t.Errorf("AddDate modified its receiver: %v", start)
+	}
+
+	later := start.Add(36 * time.Hour)
+	if later.Date().String() != "1402-07-01" || later.Hour() != 21 {
+		t.Errorf("Add(36h) = %v, expected 1402-07-01 21:30", later)
+	}
+	if later.Sub(start) != 36*time.Hour {
+		t.Errorf("Sub() = %v, expected 36h", later.Sub(start))
+	}
+	if !start.Before(later) || later.Before(start) || !later.After(start) {
+		t.Errorf("Before/After disagree for %v and %v", start, later)
+	}
+	if start.Compare(later) != -1 || later.Compare(start) != 1 || start.Compare(start) != 0 {
+		t.Errorf("Compare disagrees for %v and %v", start, later)
+	}
+	if !start.Equal(start.In(time.FixedZone("+0330", 3*3600+1800))) {
+		t.Errorf("Equal should ignore the location")
+	}
+}